@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// responseSnapshot is the protocol-independent view of a response that captures and
+// assertions are evaluated against. /
+// responseSnapshot adalah tampilan response yang tidak bergantung pada protokol, tempat
+// capture dan assertion dievaluasi.
+type responseSnapshot struct {
+	StatusCode int
+	Status     string
+	Headers    http.Header
+	Body       []byte
+	Duration   time.Duration
+}
+
+// AssertionResult is the outcome of evaluating a single Assertion.
+// AssertionResult adalah hasil dari evaluasi satu Assertion.
+type AssertionResult struct {
+	Assertion Assertion
+	Actual    string
+	Passed    bool
+	Message   string
+}
+
+// String formats an assertion in the same one-line syntax accepted by parseAssertion.
+// String memformat assertion dalam sintaks satu baris yang sama dengan yang diterima parseAssertion.
+func (as Assertion) String() string {
+	if as.Expected == "" {
+		return fmt.Sprintf("%s %s", as.Source, as.Operator)
+	}
+	return fmt.Sprintf("%s %s %s", as.Source, as.Operator, as.Expected)
+}
+
+// String formats a capture in the same one-line syntax accepted by parseCapture.
+// String memformat capture dalam sintaks satu baris yang sama dengan yang diterima parseCapture.
+func (c Capture) String() string {
+	return fmt.Sprintf("%s = %s", c.Variable, c.Source)
+}
+
+// parseAssertion parses a line such as `status == 200` or `json.data.id exists`.
+// parseAssertion mem-parse baris seperti `status == 200` atau `json.data.id exists`.
+func parseAssertion(line string) (Assertion, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return Assertion{}, fmt.Errorf("invalid assertion %q: expected '<source> <operator> [value]'", line)
+	}
+	as := Assertion{Source: fields[0], Operator: fields[1]}
+	switch as.Operator {
+	case "==", "!=", "<", ">", "contains":
+		if len(fields) < 3 {
+			return Assertion{}, fmt.Errorf("invalid assertion %q: operator '%s' needs a value", line, as.Operator)
+		}
+		// Keep the original spacing of the expected value. / Mempertahankan spasi asli dari nilai yang diharapkan.
+		rest := strings.TrimSpace(line)
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, fields[0]))
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, fields[1]))
+		as.Expected = strings.Trim(rest, `"`)
+	case "exists":
+	default:
+		return Assertion{}, fmt.Errorf("invalid assertion %q: unknown operator '%s'", line, as.Operator)
+	}
+	return as, nil
+}
+
+// parseCapture parses a line such as `token = json.data.token`.
+// parseCapture mem-parse baris seperti `token = json.data.token`.
+func parseCapture(line string) (Capture, error) {
+	name, source, ok := strings.Cut(line, "=")
+	name, source = strings.TrimSpace(name), strings.TrimSpace(source)
+	if !ok || name == "" || source == "" {
+		return Capture{}, fmt.Errorf("invalid capture %q: expected '<variable> = <source>'", line)
+	}
+	return Capture{Variable: name, Source: source}, nil
+}
+
+// extractValue resolves a source expression against a response. Supported sources are
+// `status`, `body`, `duration` (milliseconds), `header.<Name>` and `json.<path>`. /
+// extractValue me-resolve sebuah ekspresi source terhadap response. Source yang didukung adalah
+// `status`, `body`, `duration` (milidetik), `header.<Name>` dan `json.<path>`.
+func extractValue(source string, resp *responseSnapshot) (string, bool) {
+	switch {
+	case source == "status":
+		return strconv.Itoa(resp.StatusCode), true
+	case source == "body":
+		return string(resp.Body), true
+	case source == "duration":
+		return strconv.FormatInt(resp.Duration.Milliseconds(), 10), true
+	case strings.HasPrefix(source, "header."):
+		values := resp.Headers.Values(strings.TrimPrefix(source, "header."))
+		if len(values) == 0 {
+			return "", false
+		}
+		return strings.Join(values, ", "), true
+	case strings.HasPrefix(source, "json."):
+		var doc interface{}
+		if err := json.Unmarshal(resp.Body, &doc); err != nil {
+			return "", false
+		}
+		return lookupJSONPath(doc, strings.TrimPrefix(source, "json."))
+	}
+	return "", false
+}
+
+// lookupJSONPath walks a decoded JSON document using a dotted path such as
+// `data.items[0].id` (or `data.items.0.id`) and returns the value as text. /
+// lookupJSONPath menelusuri dokumen JSON yang sudah di-decode menggunakan path bertitik seperti
+// `data.items[0].id` (atau `data.items.0.id`) dan mengembalikan nilainya sebagai teks.
+func lookupJSONPath(doc interface{}, path string) (string, bool) {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	current := doc
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			continue
+		}
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return "", false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			current = node[index]
+		default:
+			return "", false
+		}
+	}
+
+	if s, ok := current.(string); ok {
+		return s, true
+	}
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return "", false
+	}
+	return string(encoded), true
+}
+
+// evaluateAssertion checks a single assertion against a response.
+// evaluateAssertion memeriksa satu assertion terhadap sebuah response.
+func evaluateAssertion(as Assertion, resp *responseSnapshot) AssertionResult {
+	actual, found := extractValue(as.Source, resp)
+	result := AssertionResult{Assertion: as, Actual: actual}
+
+	switch as.Operator {
+	case "exists":
+		result.Passed = found
+	case "==":
+		result.Passed = found && actual == as.Expected
+	case "!=":
+		result.Passed = !found || actual != as.Expected
+	case "contains":
+		result.Passed = found && strings.Contains(actual, as.Expected)
+	case "<", ">":
+		a, errA := strconv.ParseFloat(actual, 64)
+		e, errE := strconv.ParseFloat(as.Expected, 64)
+		if found && errA == nil && errE == nil {
+			result.Passed = (as.Operator == "<" && a < e) || (as.Operator == ">" && a > e)
+		}
+	default:
+		result.Message = fmt.Sprintf("unknown operator '%s'", as.Operator)
+		return result
+	}
+
+	if !result.Passed {
+		if !found {
+			result.Message = fmt.Sprintf("%s: value not found", as)
+		} else {
+			result.Message = fmt.Sprintf("%s: got %q", as, actual)
+		}
+	}
+	return result
+}
+
+// applyCaptures stores captured response values as variables. Captures whose source
+// cannot be resolved are skipped. /
+// applyCaptures menyimpan nilai response yang di-capture sebagai variabel. Capture yang source-nya
+// tidak dapat di-resolve akan dilewati.
+func applyCaptures(captures []Capture, resp *responseSnapshot, sv *scriptVariables) {
+	for _, c := range captures {
+		if value, ok := extractValue(c.Source, resp); ok {
+			sv.setVar(c.Variable, value)
+		}
+	}
+}
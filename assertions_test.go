@@ -0,0 +1,184 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testResponse() *responseSnapshot {
+	return &responseSnapshot{
+		StatusCode: 201,
+		Status:     "201 Created",
+		Headers:    http.Header{"Content-Type": {"application/json"}, "Set-Cookie": {"a=1", "b=2"}},
+		Body:       []byte(`{"data": {"id": 42, "name": "Ann Lee", "token": "t0k", "items": [{"id": "x"}, {"id": "y"}], "ok": true, "none": null}}`),
+		Duration:   150 * time.Millisecond,
+	}
+}
+
+func TestParseAssertion(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Assertion
+		wantErr bool
+	}{
+		{line: "status == 200", want: Assertion{Source: "status", Operator: "==", Expected: "200"}},
+		{line: "  json.data.id   exists ", want: Assertion{Source: "json.data.id", Operator: "exists"}},
+		{line: "json.data.name == Ann  Lee", want: Assertion{Source: "json.data.name", Operator: "==", Expected: "Ann  Lee"}},
+		{line: `body contains "quoted"`, want: Assertion{Source: "body", Operator: "contains", Expected: "quoted"}},
+		{line: "duration < 500", want: Assertion{Source: "duration", Operator: "<", Expected: "500"}},
+		{line: "status", wantErr: true},
+		{line: "status ==", wantErr: true},
+		{line: "status === 200", wantErr: true},
+		{line: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseAssertion(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAssertion(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseAssertion(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseCapture(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    Capture
+		wantErr bool
+	}{
+		{line: "token = json.data.token", want: Capture{Variable: "token", Source: "json.data.token"}},
+		{line: "id=header.X-Id", want: Capture{Variable: "id", Source: "header.X-Id"}},
+		{line: "token json.data.token", wantErr: true},
+		{line: " = status", wantErr: true},
+		{line: "code = ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseCapture(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCapture(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseCapture(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestAssertionAndCaptureStringRoundTrip(t *testing.T) {
+	for _, line := range []string{"status == 200", "json.data.id exists", "body contains a b"} {
+		as, err := parseAssertion(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if as.String() != line {
+			t.Errorf("Assertion.String() = %q, want %q", as.String(), line)
+		}
+	}
+	c := Capture{Variable: "token", Source: "json.token"}
+	if parsed, err := parseCapture(c.String()); err != nil || parsed != c {
+		t.Errorf("parseCapture(%q) = %+v, %v", c.String(), parsed, err)
+	}
+}
+
+func TestExtractValue(t *testing.T) {
+	tests := []struct {
+		source    string
+		want      string
+		wantFound bool
+	}{
+		{"status", "201", true},
+		{"duration", "150", true},
+		{"header.content-type", "application/json", true},
+		{"header.Set-Cookie", "a=1, b=2", true},
+		{"header.X-Missing", "", false},
+		{"json.data.id", "42", true},
+		{"json.data.name", "Ann Lee", true},
+		{"json.data.items[1].id", "y", true},
+		{"json.data.items.0.id", "x", true},
+		{"json.data.items", `[{"id":"x"},{"id":"y"}]`, true},
+		{"json.data.ok", "true", true},
+		{"json.data.none", "null", true},
+		{"json.data.items[2].id", "", false},
+		{"json.data.items[-1]", "", false},
+		{"json.data.id.deeper", "", false},
+		{"json.data.missing", "", false},
+		{"cookie.a", "", false},
+	}
+	resp := testResponse()
+	for _, tt := range tests {
+		got, found := extractValue(tt.source, resp)
+		if got != tt.want || found != tt.wantFound {
+			t.Errorf("extractValue(%q) = %q, %v, want %q, %v", tt.source, got, found, tt.want, tt.wantFound)
+		}
+	}
+
+	if _, found := extractValue("json.a", &responseSnapshot{Body: []byte("<html>")}); found {
+		t.Error("extractValue on a non-JSON body found a value")
+	}
+}
+
+func TestEvaluateAssertion(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"status == 201", true},
+		{"status == 200", false},
+		{"status != 200", true},
+		{"json.data.missing != x", true},
+		{"json.data.id exists", true},
+		{"json.data.missing exists", false},
+		{"body contains Ann Lee", true},
+		{"json.data.missing contains x", false},
+		{"duration < 500", true},
+		{"duration > 500", false},
+		{"json.data.id > 41.5", true},
+		{"json.data.name > 1", false},
+		{"header.Content-Type == application/json", true},
+	}
+	resp := testResponse()
+	for _, tt := range tests {
+		as, err := parseAssertion(tt.line)
+		if err != nil {
+			t.Fatalf("parseAssertion(%q): %v", tt.line, err)
+		}
+		result := evaluateAssertion(as, resp)
+		if result.Passed != tt.want {
+			t.Errorf("%q passed = %v, want %v (actual %q)", tt.line, result.Passed, tt.want, result.Actual)
+		}
+		if result.Passed == (result.Message != "") {
+			t.Errorf("%q message = %q with passed = %v", tt.line, result.Message, result.Passed)
+		}
+	}
+
+	if result := evaluateAssertion(Assertion{Source: "status", Operator: "~="}, resp); result.Passed || result.Message == "" {
+		t.Errorf("unknown operator result = %+v", result)
+	}
+}
+
+func TestApplyCaptures(t *testing.T) {
+	sv := newScriptVariables(map[string]string{"token": "old", "keep": "1"})
+	sv.overrides = map[string]string{"token": "from --var"}
+	applyCaptures([]Capture{
+		{Variable: "token", Source: "json.data.token"},
+		{Variable: "first", Source: "json.data.items[0].id"},
+		{Variable: "keep", Source: "json.data.missing"},
+	}, testResponse(), sv)
+
+	want := map[string]string{"token": "t0k", "first": "x", "keep": "1"}
+	for k, v := range want {
+		if sv.vars[k] != v {
+			t.Errorf("vars[%s] = %q, want %q", k, sv.vars[k], v)
+		}
+	}
+	if len(sv.set) != 2 {
+		t.Errorf("recorded changes = %v, want only the resolved captures", sv.set)
+	}
+	if _, ok := sv.overrides["token"]; ok {
+		t.Error("a capture did not replace the --var override")
+	}
+}
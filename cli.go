@@ -0,0 +1,510 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cliUsage is printed when the command line cannot be parsed.
+// cliUsage dicetak ketika command line tidak dapat di-parse.
+const cliUsage = `Usage:
+  panggil                                   Start the terminal UI
+  panggil <file.http>                       Start the terminal UI with a .http file as a live collection
+  panggil run <collection-path> [flags]     Run a folder or request without the UI
+  panggil send <request-path> [flags]       Send a single request and print the response
+  panggil import <file>...                  Import Postman, OpenAPI/Swagger, HAR or .http files
+  panggil export [collection-path] [flags]  Export a folder (default: all collections)
+  panggil storage <json|files>              Store collections in collections.json or one file per request
+  panggil trust                             List the workspace's command variables and allow them to run
+
+Every command accepts a leading --workspace <dir> to use the project workspace in <dir>/.panggil
+instead of the one found from the working directory.
+
+Run flags:
+  --env <name>         Environment to use (default: the active environment)
+  --iterations <n>     Number of iterations (default: 1)
+  --data <file>        CSV or JSON data file, one iteration per row
+  --bail               Stop on the first failure
+  --junit <file>       Write a JUnit XML report
+  --json <file>        Write a JSON report with full request/response details
+
+Send flags:
+  --env <name>         Environment to use (default: the active environment)
+  --var <name=value>   Override a variable (repeatable)
+  --output <format>    One of body, headers, status, json (default: body)
+
+Export flags:
+  --format <name>      Export format: postman, http (default: postman)
+  --out <file>         Write to a file instead of stdout
+`
+
+// runCLI executes a headless command and returns the process exit code: 0 on success,
+// 1 when requests or assertions failed, and 2 for usage or configuration errors. /
+// runCLI menjalankan command headless dan mengembalikan exit code proses: 0 jika berhasil,
+// 1 jika ada request atau assertion yang gagal, dan 2 untuk kesalahan penggunaan atau konfigurasi.
+func runCLI(args []string, stdout, stderr io.Writer) int {
+	switch args[0] {
+	case "run":
+		return runCommand(args[1:], stdout, stderr)
+	case "send":
+		return sendCommand(args[1:], stdout, stderr)
+	case "import":
+		return importCommand(args[1:], stdout, stderr)
+	case "export":
+		return exportCommand(args[1:], stdout, stderr)
+	case "storage":
+		return storageCommand(args[1:], stdout, stderr)
+	case "trust":
+		return trustCommand(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, cliUsage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], cliUsage)
+		return 2
+	}
+}
+
+// runCommand implements `panggil run`.
+// runCommand mengimplementasikan `panggil run`.
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	envName := fs.String("env", "", "environment to use")
+	iterations := fs.Int("iterations", 1, "number of iterations")
+	dataFile := fs.String("data", "", "CSV or JSON data file")
+	bail := fs.Bool("bail", false, "stop on the first failure")
+	junitPath := fs.String("junit", "", "JUnit XML report file")
+	jsonPath := fs.String("json", "", "JSON report file")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(stderr, "run expects exactly one collection path\n\n%s", cliUsage)
+		return 2
+	}
+
+	a := newHeadlessApp()
+	node := findNodeByPath(a.collectionsRoot, positional[0])
+	if node == nil {
+		fmt.Fprintf(stderr, "collection path %q not found\n", positional[0])
+		return 2
+	}
+	env, err := a.findEnvironment(*envName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	opts := RunOptions{
+		Iterations:    *iterations,
+		StopOnFailure: *bail,
+		Variables:     env.allVariables(),
+		Commands:      env.Commands,
+		Scopes:        a.collectionScopes(node),
+	}
+	if *dataFile != "" {
+		rows, err := loadDataRows(*dataFile)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		opts.DataRows = rows
+	}
+
+	fmt.Fprintf(stdout, "Running %q with environment %q\n\n", positional[0], env.Name)
+	info := RunReportInfo{Collection: node.Name, Environment: env.Name, StartedAt: time.Now()}
+	results := runCollection(context.Background(), node, opts, func(r RunResult) {
+		printRunResult(stdout, r)
+	})
+	info.FinishedAt = time.Now()
+	info.Redactor = a.secretRedactor()
+
+	if err := writeRunReports(*junitPath, *jsonPath, info, results); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	passed, failed, total := summarizeRun(results)
+	fmt.Fprintf(stdout, "\n%d requests, %d passed, %d failed, total %v\n", len(results), passed, failed, total.Round(time.Millisecond))
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// sendCommand implements `panggil send`.
+// sendCommand mengimplementasikan `panggil send`.
+func sendCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	envName := fs.String("env", "", "environment to use")
+	output := fs.String("output", "body", "output format: body, headers, status or json")
+	overrides := make(varFlag)
+	fs.Var(overrides, "var", "variable override as name=value (repeatable)")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(stderr, "send expects exactly one request path\n\n%s", cliUsage)
+		return 2
+	}
+	switch *output {
+	case "body", "headers", "status", "json":
+	default:
+		fmt.Fprintf(stderr, "unknown output format %q (expected body, headers, status or json)\n", *output)
+		return 2
+	}
+
+	a := newHeadlessApp()
+	node := findNodeByPath(a.collectionsRoot, positional[0])
+	if node == nil || node.IsFolder || node.Request == nil {
+		fmt.Fprintf(stderr, "request %q not found\n", positional[0])
+		return 2
+	}
+	env, err := a.findEnvironment(*envName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	pool := newGrpcSessionPool()
+	defer pool.Close()
+	console := &scriptConsole{}
+	sv := newScriptVariables(env.allVariables())
+	sv.commands = env.Commands
+	sv.overrides = overrides
+	result := runRequest(pool, node.Request, a.collectionScopes(node), sv, console)
+	for _, line := range console.lines {
+		fmt.Fprintf(stderr, "console: %s\n", line)
+	}
+	if result.Error != nil {
+		fmt.Fprintf(stderr, "error: %v\n", result.Error)
+		return 1
+	}
+
+	printResponse(stdout, result, *output)
+	if result.ScriptError != nil {
+		fmt.Fprintf(stderr, "error: %v\n", result.ScriptError)
+	}
+	for _, as := range result.Assertions {
+		if !as.Passed {
+			fmt.Fprintf(stderr, "assertion failed: %s\n", as.Message)
+		}
+	}
+	if !result.Passed() {
+		return 1
+	}
+	return 0
+}
+
+// importCommand implements `panggil import`. Each file is imported independently; skipped
+// features are printed as warnings. /
+// importCommand mengimplementasikan `panggil import`. Setiap file di-import secara terpisah; fitur
+// yang dilewati dicetak sebagai peringatan.
+func importCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintf(stderr, "import expects at least one file\n\n%s", cliUsage)
+		return 2
+	}
+
+	a := newHeadlessApp()
+	exitCode := 0
+	for _, path := range args {
+		result, err := importFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			exitCode = 1
+			continue
+		}
+		a.applyImport(result)
+		fmt.Fprintln(stdout, result.summary())
+		for _, w := range result.Warnings {
+			fmt.Fprintf(stderr, "  warning: %s\n", w)
+		}
+	}
+	return exitCode
+}
+
+// exportCommand implements `panggil export`.
+// exportCommand mengimplementasikan `panggil export`.
+func exportCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	formatName := fs.String("format", "postman", "export format")
+	outPath := fs.String("out", "", "output file")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) > 1 {
+		fmt.Fprintf(stderr, "export expects at most one collection path\n\n%s", cliUsage)
+		return 2
+	}
+
+	format, ok := map[string]string{"postman": "Postman v2.1", "http": "HTTP file (.http)"}[strings.ToLower(*formatName)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown export format %q\n", *formatName)
+		return 2
+	}
+
+	a := newHeadlessApp()
+	path := ""
+	if len(positional) == 1 {
+		path = positional[0]
+	}
+	node := findNodeByPath(a.collectionsRoot, path)
+	if node == nil {
+		fmt.Fprintf(stderr, "collection path %q not found\n", path)
+		return 2
+	}
+
+	if *outPath != "" {
+		if err := exportCollectionToFile(node, format, *outPath); err != nil {
+			fmt.Fprintf(stderr, "error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	data, err := exportCollection(node, format)
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, string(data))
+	return 0
+}
+
+// storageCommand implements `panggil storage`.
+// storageCommand mengimplementasikan `panggil storage`.
+func storageCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintf(stderr, "storage expects json or files\n\n%s", cliUsage)
+		return 2
+	}
+	a := newHeadlessApp()
+	path, err := a.convertCollectionStorage(args[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "error: %v\n", err)
+		return 2
+	}
+	fmt.Fprintf(stdout, "Collections are now stored in %s\n", path)
+	return 0
+}
+
+// trustCommand implements `panggil trust`, which prints every command variable of the workspace
+// that is not trusted yet and allows it to run. /
+// trustCommand mengimplementasikan `panggil trust`, yang mencetak setiap variabel perintah
+// workspace yang belum dipercaya dan mengizinkannya untuk dijalankan.
+func trustCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprintf(stderr, "trust takes no arguments\n\n%s", cliUsage)
+		return 2
+	}
+	a := newHeadlessApp()
+	lines, commands := untrustedCommands(a.environments)
+	if len(commands) == 0 {
+		fmt.Fprintln(stdout, "No untrusted command variables.")
+		return 0
+	}
+	fmt.Fprintf(stdout, "Trusting the command variables of %s:\n", workspaceDir())
+	for _, line := range lines {
+		fmt.Fprintf(stdout, "  %s\n", line)
+	}
+	trustCommands(commands...)
+	return 0
+}
+
+// printResponse writes the response of a single request in the selected output format.
+// printResponse menulis response dari satu request dalam format output yang dipilih.
+func printResponse(w io.Writer, r RunResult, format string) {
+	switch format {
+	case "status":
+		fmt.Fprintln(w, r.Status)
+	case "headers":
+		keys := make([]string, 0, len(r.ResponseHeaders))
+		for k := range r.ResponseHeaders {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, v := range r.ResponseHeaders[k] {
+				fmt.Fprintf(w, "%s: %s\n", k, v)
+			}
+		}
+	case "json":
+		out := struct {
+			Status     string          `json:"status"`
+			StatusCode int             `json:"status_code,omitempty"`
+			DurationMs float64         `json:"duration_ms"`
+			Headers    http.Header     `json:"headers,omitempty"`
+			Body       json.RawMessage `json:"body"`
+		}{
+			Status:     r.Status,
+			StatusCode: r.StatusCode,
+			DurationMs: float64(r.Duration.Microseconds()) / 1000,
+			Headers:    r.ResponseHeaders,
+		}
+		// Embed JSON bodies as-is and everything else as a string.
+		// Sisipkan body JSON apa adanya dan selain itu sebagai string.
+		if json.Valid(r.ResponseBody) {
+			out.Body = r.ResponseBody
+		} else {
+			out.Body, _ = json.Marshal(string(r.ResponseBody))
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Fprintln(w, string(data))
+	default:
+		w.Write(r.ResponseBody)
+		if len(r.ResponseBody) > 0 && r.ResponseBody[len(r.ResponseBody)-1] != '\n' {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// varFlag collects repeated `--var name=value` flags.
+// varFlag mengumpulkan flag `--var name=value` yang diulang.
+type varFlag map[string]string
+
+// String implements flag.Value.
+// String mengimplementasikan flag.Value.
+func (v varFlag) String() string {
+	return formatDataRow(v)
+}
+
+// Set implements flag.Value by parsing a single name=value pair.
+// Set mengimplementasikan flag.Value dengan mem-parse satu pasangan name=value.
+func (v varFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("expected name=value, got %q", value)
+	}
+	v[strings.TrimSpace(name)] = val
+	return nil
+}
+
+// printRunResult writes a single runner result as a line of plain text, followed by any failed assertions.
+// printRunResult menulis satu hasil runner sebagai satu baris teks biasa, diikuti assertion yang gagal.
+func printRunResult(w io.Writer, r RunResult) {
+	mark := "PASS"
+	if !r.Passed() {
+		mark = "FAIL"
+	}
+	status := r.Status
+	if status == "" {
+		status = "-"
+	}
+	fmt.Fprintf(w, "%s  [%d] %s  %s  %v\n", mark, r.Iteration, r.Path, status, r.Duration.Round(time.Millisecond))
+
+	for _, line := range r.Logs {
+		fmt.Fprintf(w, "      console: %s\n", line)
+	}
+	if r.Error != nil {
+		fmt.Fprintf(w, "      error: %v\n", r.Error)
+	}
+	if r.ScriptError != nil {
+		fmt.Fprintf(w, "      error: %v\n", r.ScriptError)
+	}
+	for _, as := range r.Assertions {
+		if !as.Passed {
+			fmt.Fprintf(w, "      assertion failed: %s\n", as.Message)
+		}
+	}
+	if r.Error == nil && len(r.Assertions) == 0 && !r.Passed() {
+		fmt.Fprintf(w, "      %s\n", failureReason(r))
+	}
+}
+
+// parseInterspersed parses flags that may appear before or after positional arguments.
+// parseInterspersed mem-parse flag yang dapat muncul sebelum atau sesudah argumen posisi.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// newHeadlessApp creates an App with only its persisted data loaded and no UI components.
+// newHeadlessApp membuat App yang hanya memuat data tersimpan tanpa komponen UI.
+func newHeadlessApp() *App {
+	a := &App{
+		collectionsRoot: &CollectionNode{
+			Name:     "Collections",
+			IsFolder: true,
+		},
+		grpcBodyCache: make(map[string]string),
+	}
+	a.loadCollections()
+	a.loadEnvironments()
+	return a
+}
+
+// findEnvironment returns the environment with the given name (case-insensitive), or the
+// environment active in the TUI when name is empty, which is the first one unless another was
+// selected. /
+// findEnvironment mengembalikan environment dengan nama yang diberikan (tidak peka huruf besar/kecil),
+// atau environment yang aktif di TUI jika nama kosong, yaitu yang pertama kecuali ada yang lain
+// yang dipilih.
+func (a *App) findEnvironment(name string) (*Environment, error) {
+	if len(a.environments) == 0 {
+		return nil, fmt.Errorf("no environments defined")
+	}
+	if name == "" {
+		if a.activeEnvIndex < len(a.environments) {
+			return a.environments[a.activeEnvIndex], nil
+		}
+		return a.environments[0], nil
+	}
+	var names []string
+	for _, env := range a.environments {
+		if strings.EqualFold(env.Name, name) {
+			return env, nil
+		}
+		names = append(names, env.Name)
+	}
+	return nil, fmt.Errorf("environment %q not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// findNodeByPath resolves a slash-separated path such as "Users/Get user" in the collections
+// tree. Because request names may themselves contain slashes (e.g. "GET http://host/users"),
+// a child name matches when it equals the remaining path or is followed by a slash. /
+// findNodeByPath me-resolve path dipisah garis miring seperti "Users/Get user" di tree Collections.
+// Karena nama request bisa mengandung garis miring (mis. "GET http://host/users"), nama child
+// dianggap cocok jika sama dengan sisa path atau diikuti oleh garis miring.
+func findNodeByPath(root *CollectionNode, path string) *CollectionNode {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return root
+	}
+	for _, child := range root.Children {
+		if child.Name == path {
+			return child
+		}
+		if child.IsFolder && strings.HasPrefix(path, child.Name+"/") {
+			if found := findNodeByPath(child, strings.TrimPrefix(path, child.Name+"/")); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
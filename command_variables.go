@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// defaultCommandTimeout limits a command variable that does not set its own timeout.
+// defaultCommandTimeout membatasi variabel perintah yang tidak menentukan batas waktunya sendiri.
+const defaultCommandTimeout = 10 * time.Second
+
+// commandOutput is the last output of a command and when it was produced.
+// commandOutput adalah output terakhir dari sebuah perintah dan kapan output itu dihasilkan.
+type commandOutput struct {
+	value string
+	ran   time.Time
+}
+
+// commandCache keeps the last output of each command, by command text.
+// commandCache menyimpan output terakhir dari setiap perintah, berdasarkan teks perintahnya.
+var commandCache = struct {
+	sync.Mutex
+	outputs map[string]commandOutput
+}{outputs: make(map[string]commandOutput)}
+
+// commandPlaceholder is shown instead of a command variable's value where running it would
+// block the UI, such as the variable inspector and generated scripts. /
+// commandPlaceholder ditampilkan sebagai pengganti nilai variabel perintah di tempat yang akan
+// memblokir UI jika perintahnya dijalankan, seperti inspector variabel dan script yang di-generate.
+func commandPlaceholder(cv *CommandVariable) string {
+	return "$(" + cv.Command + ")"
+}
+
+// runCommandVariable returns the standard output of cv's command without trailing newlines,
+// reusing the cached output while it is younger than the cache time. /
+// runCommandVariable mengembalikan standard output dari perintah cv tanpa baris baru di akhir,
+// dan memakai ulang output yang di-cache selama umurnya belum melewati waktu cache.
+func runCommandVariable(cv *CommandVariable) (string, error) {
+	if !commandTrusted(cv.Command) {
+		return "", errors.New("the command is not trusted in this workspace; run `panggil trust` to review and allow it")
+	}
+	if cv.CacheSeconds > 0 {
+		commandCache.Lock()
+		cached, ok := commandCache.outputs[cv.Command]
+		commandCache.Unlock()
+		if ok && time.Since(cached.ran) < time.Duration(cv.CacheSeconds)*time.Second {
+			return cached.value, nil
+		}
+	}
+
+	timeout := defaultCommandTimeout
+	if cv.TimeoutSeconds > 0 {
+		timeout = time.Duration(cv.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, cv.Command)
+	// Commands run in the project directory of the workspace, like relative .env paths.
+	// Perintah dijalankan di direktori project workspace, seperti path .env relatif.
+	if dir := workspaceDir(); dir != "" {
+		cmd.Dir = filepath.Dir(dir)
+	}
+	// Children of the shell may keep its output open after it is killed; stop waiting for them.
+	// Proses anak dari shell dapat tetap membuka output-nya setelah shell dihentikan; berhenti menunggunya.
+	cmd.WaitDelay = time.Second
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("timed out after %v", timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", err
+	}
+
+	value := strings.TrimRight(string(out), "\r\n")
+	commandCache.Lock()
+	commandCache.outputs[cv.Command] = commandOutput{value: value, ran: time.Now()}
+	commandCache.Unlock()
+	return value, nil
+}
+
+// trustedCommands guards trusted_commands.json, which keeps the fingerprints of the commands the
+// user allowed to run, per workspace. A command from a cloned project, where anyone could have
+// written environments.json, only runs once the user has seen and trusted it. /
+// trustedCommands menjaga trusted_commands.json, yang menyimpan fingerprint dari perintah yang
+// diizinkan pengguna untuk dijalankan, per workspace. Perintah dari project yang di-clone, di mana
+// siapa pun dapat menulis environments.json, hanya dijalankan setelah pengguna melihat dan mempercayainya.
+var trustedCommands sync.Mutex
+
+// loadTrustedCommands reads the trusted command fingerprints of every workspace.
+// loadTrustedCommands membaca fingerprint perintah yang dipercaya dari setiap workspace.
+func loadTrustedCommands() map[string][]string {
+	trusted := make(map[string][]string)
+	path, err := getConfigPath("trusted_commands.json")
+	if err != nil {
+		log.Printf("ERROR: Could not get config path for trusted commands: %v", err)
+		return trusted
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("ERROR: Failed to read trusted commands: %v", err)
+		}
+		return trusted
+	}
+	if err := json.Unmarshal(data, &trusted); err != nil {
+		log.Printf("ERROR: Failed to unmarshal trusted commands: %v", err)
+	}
+	return trusted
+}
+
+// commandTrusted reports whether command may run in the active workspace. Commands of the user
+// config directory were all entered by the user, so they are always trusted. /
+// commandTrusted melaporkan apakah command boleh dijalankan di workspace yang aktif. Perintah di
+// direktori config pengguna semuanya dimasukkan oleh pengguna, sehingga selalu dipercaya.
+func commandTrusted(command string) bool {
+	dir := workspaceDir()
+	if dir == "" {
+		return true
+	}
+	trustedCommands.Lock()
+	defer trustedCommands.Unlock()
+	return slices.Contains(loadTrustedCommands()[dir], contentFingerprint([]byte(command)))
+}
+
+// trustCommands allows commands to run in the active workspace.
+// trustCommands mengizinkan commands untuk dijalankan di workspace yang aktif.
+func trustCommands(commands ...string) {
+	dir := workspaceDir()
+	if dir == "" || len(commands) == 0 {
+		return
+	}
+	trustedCommands.Lock()
+	defer trustedCommands.Unlock()
+	trusted := loadTrustedCommands()
+	for _, command := range commands {
+		if fingerprint := contentFingerprint([]byte(command)); !slices.Contains(trusted[dir], fingerprint) {
+			trusted[dir] = append(trusted[dir], fingerprint)
+		}
+	}
+	path, err := getConfigPath("trusted_commands.json")
+	if err != nil {
+		log.Printf("ERROR: Could not get config path for trusted commands: %v", err)
+		return
+	}
+	data, _ := json.MarshalIndent(trusted, "", "  ")
+	if err := writeFileAtomic(path, data, 0600); err != nil {
+		log.Printf("ERROR: Failed to write trusted commands: %v", err)
+	}
+}
+
+// untrustedCommands lists the command variables of envs that may not run yet, as
+// "environment: name = command" lines, and returns their commands. /
+// untrustedCommands mendaftar variabel perintah dari envs yang belum boleh dijalankan, sebagai
+// baris "environment: name = command", dan mengembalikan perintahnya.
+func untrustedCommands(envs []*Environment) (lines, commands []string) {
+	for _, env := range envs {
+		names := make([]string, 0, len(env.Commands))
+		for name := range env.Commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			command := env.Commands[name].Command
+			if commandTrusted(command) {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s: %s = %s", env.Name, name, command))
+			if !slices.Contains(commands, command) {
+				commands = append(commands, command)
+			}
+		}
+	}
+	return lines, commands
+}
+
+// confirmWorkspaceCommands asks whether the command variables of the workspace that are not
+// trusted yet may run, showing each command. Declining leaves them blocked until asked again. /
+// confirmWorkspaceCommands menanyakan apakah variabel perintah workspace yang belum dipercaya
+// boleh dijalankan, dengan menampilkan setiap perintahnya. Menolak membiarkannya tetap diblokir
+// sampai ditanyakan lagi.
+func (a *App) confirmWorkspaceCommands() {
+	lines, commands := untrustedCommands(a.environments)
+	if len(commands) == 0 || a.rootPages.HasPage("commandTrustModal") {
+		return
+	}
+	returnFocus := a.app.GetFocus()
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("The workspace %s defines command variables that run shell commands:\n\n%s\n\nOnly trust them if you trust the project.", workspaceName(workspaceDir()), strings.Join(lines, "\n"))).
+		AddButtons([]string{"Trust", "Not Now"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.rootPages.RemovePage("commandTrustModal")
+			a.app.SetFocus(returnFocus)
+			if buttonLabel != "Trust" {
+				a.statusText.SetText("[yellow]Workspace commands are not trusted; requests using them will fail")
+				return
+			}
+			trustCommands(commands...)
+			log.Printf("INFO: Trusted %d command(s) of workspace %s", len(commands), workspaceDir())
+			a.statusText.SetText("[green]Workspace commands trusted")
+		})
+	a.rootPages.AddPage("commandTrustModal", modal, true, true)
+	a.app.SetFocus(modal)
+}
+
+// shellCommand runs command through the platform shell, so pipes and quoting work as typed.
+// shellCommand menjalankan command melalui shell platform, sehingga pipe dan kutip bekerja seperti yang diketik.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// resolveCommandVariables runs the command variables that req uses, through a {{placeholder}}
+// in its fields or in the value of another variable in vars, or through env.get in its scripts. /
+// resolveCommandVariables menjalankan variabel perintah yang dipakai req, melalui {{placeholder}}
+// di field-nya atau di nilai variabel lain di vars, atau melalui env.get di script-nya.
+func resolveCommandVariables(commands map[string]*CommandVariable, req *Request, vars map[string]string) (map[string]string, error) {
+	if len(commands) == 0 {
+		return nil, nil
+	}
+	texts := []string{req.URL, req.HeadersRaw, req.Body, req.AuthToken, req.AuthUser, req.AuthPass, req.GrpcServer, req.GrpcMetadata}
+	for _, v := range req.Headers {
+		texts = append(texts, v)
+	}
+	for _, v := range vars {
+		texts = append(texts, v)
+	}
+	used := make(map[string]bool)
+	for _, name := range findVariableNames(texts...) {
+		used[name] = true
+	}
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make(map[string]string)
+	for _, name := range names {
+		if !used[name] && !scriptReadsVariable(req.PreRequestScript, name) && !scriptReadsVariable(req.PostResponseScript, name) {
+			continue
+		}
+		value, err := runCommandVariable(commands[name])
+		if err != nil {
+			log.Printf("ERROR: Command variable '%s' failed: %v", name, err)
+			return nil, fmt.Errorf("command variable %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// scriptReadsVariable reports whether script reads the variable name with env.get("name"), or
+// through a {{name}} placeholder it passes to env.replace. /
+// scriptReadsVariable melaporkan apakah script membaca variabel name dengan env.get("name"), atau
+// melalui placeholder {{name}} yang diberikan ke env.replace.
+func scriptReadsVariable(script, name string) bool {
+	if !strings.Contains(script, name) {
+		return false
+	}
+	if slices.Contains(findVariableNames(script), name) {
+		return true
+	}
+	get := regexp.MustCompile(`env\.get\(\s*["'\x60]` + regexp.QuoteMeta(name) + `["'\x60]\s*\)`)
+	return get.MatchString(script)
+}
+
+// commandOutputs returns the last output of every command run so far, for redacting exports.
+// commandOutputs mengembalikan output terakhir dari setiap perintah yang sudah dijalankan, untuk menyamarkan export.
+func commandOutputs() []string {
+	commandCache.Lock()
+	defer commandCache.Unlock()
+	var values []string
+	for _, out := range commandCache.outputs {
+		if out.value != "" {
+			values = append(values, out.value)
+		}
+	}
+	return values
+}
+
+// activeCommands returns the command variables of the active environment.
+// activeCommands mengembalikan variabel perintah dari environment yang aktif.
+func (a *App) activeCommands() map[string]*CommandVariable {
+	if len(a.environments) == 0 || a.activeEnvIndex >= len(a.environments) {
+		return nil
+	}
+	return a.environments[a.activeEnvIndex].Commands
+}
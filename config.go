@@ -2,10 +2,13 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // getConfigPath returns the absolute path for a configuration file, ensuring it's
@@ -25,6 +28,19 @@ func getConfigPath(filename string) (string, error) {
 	return filepath.Join(appConfigDir, filename), nil
 }
 
+// expandHomePath expands a leading "~/" in a user-entered path to the home directory.
+// expandHomePath mengubah awalan "~/" pada path yang diketik user menjadi direktori home.
+func expandHomePath(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
 // initLogger sets up the application's logger to write to a file.
 // initLogger mengatur logger aplikasi untuk menulis log ke sebuah file.
 func initLogger() {
@@ -41,38 +57,69 @@ func initLogger() {
 	log.Println("INFO: Logger initialized. Application starting.")
 }
 
-// saveCollections serializes the collections data to a JSON file.
-// saveCollections melakukan serialisasi data Collections ke file JSON.
+// saveCollections serializes the collections data to a JSON file, or to one file per
+// request when a collections directory exists. It is called after every change. /
+// saveCollections melakukan serialisasi data Collections ke file JSON, atau ke satu file per
+// request jika direktori collections ada. Dipanggil setelah setiap perubahan.
 func (a *App) saveCollections() {
-	path, err := getConfigPath("collections.json")
+	// Live .http folders are saved to their own files.
+	// Folder .http live disimpan ke file-nya masing-masing.
+	a.saveLiveFiles()
+	root, err := sealCollection(a.persistedCollections())
 	if err != nil {
-		log.Printf("ERROR: Could not get config path for collections: %v", err)
+		log.Printf("ERROR: Failed to encrypt collection secrets: %v", err)
+		return
+	}
+
+	if dir, err := getWorkspacePath(collectionsDirName); err == nil && isDirectory(dir) {
+		if err := loadError(dir); err != nil {
+			log.Printf("ERROR: Not writing collections directory %s because it could not be loaded: %v", dir, err)
+			return
+		}
+		if known, watched := syncedFingerprint(dir); watched && fileFingerprint(dir) != known {
+			setConflict(dir, true)
+			log.Printf("ERROR: Not writing collections directory %s: %v", dir, errChangedOnDisk)
+			return
+		}
+		if err := saveCollectionDir(root, dir); err != nil {
+			log.Printf("ERROR: Failed to write collections directory: %v", err)
+		}
+		markSynced(dir, fileFingerprint(dir))
 		return
 	}
-	data, err := json.MarshalIndent(a.collectionsRoot, "", "  ")
+
+	path, err := getWorkspacePath("collections.json")
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal collections: %v", err)
+		log.Printf("ERROR: Could not get config path for collections: %v", err)
 		return
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeSchemaFile(path, root, 0600); err != nil {
 		log.Printf("ERROR: Failed to write collections file: %v", err)
 	}
 }
 
+// persistedCollections returns the collections tree without live .http folders.
+// persistedCollections mengembalikan tree Collections tanpa folder .http live.
+func (a *App) persistedCollections() *CollectionNode {
+	root := *a.collectionsRoot
+	root.Children = nil
+	for _, child := range a.collectionsRoot.Children {
+		if child.SourceFile == "" {
+			root.Children = append(root.Children, child)
+		}
+	}
+	return &root
+}
+
 // saveGrpcCache serializes the gRPC request body cache to a JSON file.
 // saveGrpcCache melakukan serialisasi cache body request gRPC ke file JSON.
 func (a *App) saveGrpcCache() {
-	path, err := getConfigPath("grpc_cache.json")
+	path, err := getWorkspacePath("grpc_cache.json")
 	if err != nil {
 		log.Printf("ERROR: Could not get config path for gRPC cache: %v", err)
 		return
 	}
-	data, err := json.MarshalIndent(a.grpcBodyCache, "", "  ")
-	if err != nil {
-		log.Printf("ERROR: Failed to marshal gRPC cache: %v", err)
-		return
-	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeSchemaFile(path, a.grpcBodyCache, 0644); err != nil {
 		log.Printf("ERROR: Failed to write gRPC cache file: %v", err)
 	}
 }
@@ -80,9 +127,10 @@ func (a *App) saveGrpcCache() {
 // loadEnvironments reads the environments data from a JSON file.
 // loadEnvironments membaca data environments dari file JSON.
 func (a *App) loadEnvironments() {
-	path, _ := getConfigPath("environments.json")
-	data, err := os.ReadFile(path)
-	if err != nil {
+	path, _ := getWorkspacePath("environments.json")
+	markSynced(path, fileFingerprint(path))
+	data, err := readSchemaFile(path, "environments")
+	if errors.Is(err, fs.ErrNotExist) {
 		log.Printf("INFO: Environments file not found, creating default environment")
 		// Create default environment
 		a.environments = []*Environment{
@@ -93,8 +141,15 @@ func (a *App) loadEnvironments() {
 		}
 		return
 	}
-	if err := json.Unmarshal(data, &a.environments); err != nil {
-		log.Printf("ERROR: Failed to unmarshal environments: %v", err)
+	if err == nil {
+		err = json.Unmarshal(data, &a.environments)
+	}
+	if err != nil {
+		markLoadFailed(path, err)
+	} else {
+		markLoaded(path)
+	}
+	if err != nil || len(a.environments) == 0 {
 		a.environments = []*Environment{
 			{
 				Name:      "Default",
@@ -102,22 +157,32 @@ func (a *App) loadEnvironments() {
 			},
 		}
 	}
+	openEnvironmentSecrets(a.environments)
+	a.activeEnvIndex = 0
+	for i, env := range a.environments {
+		if env.Active {
+			a.activeEnvIndex = i
+		}
+	}
 }
 
 // saveEnvironments serializes the environments data to a JSON file.
 // saveEnvironments melakukan serialisasi data environments ke file JSON.
 func (a *App) saveEnvironments() {
-	path, err := getConfigPath("environments.json")
+	path, err := getWorkspacePath("environments.json")
 	if err != nil {
 		log.Printf("ERROR: Could not get config path for environments: %v", err)
 		return
 	}
-	data, err := json.MarshalIndent(a.environments, "", "  ")
+	for i, env := range a.environments {
+		env.Active = i == a.activeEnvIndex
+	}
+	sealed, err := sealEnvironments(a.environments)
 	if err != nil {
-		log.Printf("ERROR: Failed to marshal environments: %v", err)
+		log.Printf("ERROR: Failed to encrypt environment secrets: %v", err)
 		return
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeSchemaFile(path, sealed, 0600); err != nil {
 		log.Printf("ERROR: Failed to write environments file: %v", err)
 	}
 }
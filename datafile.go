@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// loadDataRows reads a CSV or JSON data file used for data-driven collection runs. Each
+// row becomes a map of variable name to value. CSV files must have a header row; JSON
+// files must contain an array of objects. /
+// loadDataRows membaca file data CSV atau JSON untuk menjalankan collection berbasis data.
+// Setiap baris menjadi map dari nama variabel ke nilai. File CSV harus memiliki baris header;
+// file JSON harus berisi array of object.
+func loadDataRows(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading data file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseCSVRows(data)
+	case ".json":
+		return parseJSONRows(data)
+	default:
+		return nil, fmt.Errorf("unsupported data file type '%s' (expected .csv or .json)", filepath.Ext(path))
+	}
+}
+
+// parseCSVRows converts CSV content with a header row into variable rows.
+// parseCSVRows mengkonversi konten CSV dengan baris header menjadi baris variabel.
+func parseCSVRows(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("CSV file needs a header row and at least one data row")
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				row[strings.TrimSpace(name)] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseJSONRows converts a JSON array of objects into variable rows. Non-string values
+// are kept in their JSON form so they can be substituted into request bodies directly. /
+// parseJSONRows mengkonversi array JSON of object menjadi baris variabel. Nilai yang bukan string
+// disimpan dalam bentuk JSON-nya agar dapat langsung disubstitusi ke body request.
+func parseJSONRows(data []byte) ([]map[string]string, error) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("parsing JSON data file (expected an array of objects): %w", err)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("JSON data file contains no rows")
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, obj := range objects {
+		row := make(map[string]string, len(obj))
+		for k, v := range obj {
+			if s, ok := v.(string); ok {
+				row[k] = s
+				continue
+			}
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("encoding value of '%s': %w", k, err)
+			}
+			row[k] = string(encoded)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
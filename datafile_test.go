@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseCSVRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []map[string]string
+		wantErr bool
+	}{
+		{
+			name: "header and rows",
+			data: "user, password\nalice, secret\nbob,\"a,b\"\n",
+			want: []map[string]string{
+				{"user": "alice", "password": "secret"},
+				{"user": "bob", "password": "a,b"},
+			},
+		},
+		{
+			name: "quoted multi-line value",
+			data: "id,note\n1,\"first\nsecond\"\n",
+			want: []map[string]string{{"id": "1", "note": "first\nsecond"}},
+		},
+		{name: "header only", data: "user,password\n", wantErr: true},
+		{name: "empty", data: "", wantErr: true},
+		{name: "ragged row", data: "a,b\n1\n", wantErr: true},
+		{name: "bare quote", data: "a\nx\"y\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCSVRows([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCSVRows error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCSVRows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSONRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []map[string]string
+		wantErr bool
+	}{
+		{
+			name: "strings and other JSON values",
+			data: `[{"user": "alice", "age": 30, "admin": true, "tags": ["a"], "meta": {"x": 1}, "none": null}]`,
+			want: []map[string]string{{"user": "alice", "age": "30", "admin": "true", "tags": `["a"]`, "meta": `{"x":1}`, "none": "null"}},
+		},
+		{
+			name: "several rows",
+			data: `[{"id": "1"}, {"id": "2"}]`,
+			want: []map[string]string{{"id": "1"}, {"id": "2"}},
+		},
+		{name: "empty array", data: `[]`, wantErr: true},
+		{name: "object instead of array", data: `{"id": 1}`, wantErr: true},
+		{name: "array of scalars", data: `[1, 2]`, wantErr: true},
+		{name: "invalid", data: `[{`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseJSONRows([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJSONRows error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseJSONRows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDataRows(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"rows.CSV":  "id\n1\n",
+		"rows.json": `[{"id": 1}]`,
+		"rows.yaml": "- id: 1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		file    string
+		wantErr bool
+	}{
+		{file: "rows.CSV"},
+		{file: "rows.json"},
+		{file: "rows.yaml", wantErr: true},
+		{file: "missing.csv", wantErr: true},
+	}
+	for _, tt := range tests {
+		rows, err := loadDataRows(filepath.Join(dir, tt.file))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("loadDataRows(%s) error = %v, wantErr %v", tt.file, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (len(rows) != 1 || rows[0]["id"] != "1") {
+			t.Errorf("loadDataRows(%s) = %v", tt.file, rows)
+		}
+	}
+}
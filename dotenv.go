@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dotenvFile is a parsed .env file and the file state it was read at.
+// dotenvFile adalah file .env yang sudah di-parse beserta keadaan file saat dibaca.
+type dotenvFile struct {
+	modTime time.Time
+	size    int64
+	vars    map[string]string
+	err     error
+}
+
+// dotenvCache keeps parsed .env files by path, so they are only read again after they change.
+// dotenvCache menyimpan file .env yang sudah di-parse berdasarkan path, sehingga hanya dibaca ulang setelah berubah.
+var dotenvCache = struct {
+	sync.Mutex
+	files map[string]*dotenvFile
+}{files: make(map[string]*dotenvFile)}
+
+// resolveDotenvPath turns a linked .env path into an absolute one. Relative paths are relative
+// to the project directory of the active workspace, or to the working directory without one. /
+// resolveDotenvPath mengubah path .env yang ditautkan menjadi absolut. Path relatif dihitung dari
+// direktori project workspace yang aktif, atau dari direktori kerja jika tidak ada workspace.
+func resolveDotenvPath(path string) string {
+	path = expandHomePath(strings.TrimSpace(path))
+	if dir := workspaceDir(); !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(filepath.Dir(dir), path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// loadDotenvFile returns the variables of the .env file at path, reading it again only when its
+// modification time or size changed. Read errors are logged once per change. /
+// loadDotenvFile mengembalikan variabel dari file .env di path, dan hanya membacanya ulang jika
+// waktu modifikasi atau ukurannya berubah. Error pembacaan dicatat sekali per perubahan.
+func loadDotenvFile(path string) map[string]string {
+	path = resolveDotenvPath(path)
+	dotenvCache.Lock()
+	defer dotenvCache.Unlock()
+
+	info, statErr := os.Stat(path)
+	cached := dotenvCache.files[path]
+	if cached != nil {
+		if statErr != nil && cached.err != nil {
+			return nil
+		}
+		if statErr == nil && cached.err == nil && info.ModTime().Equal(cached.modTime) && info.Size() == cached.size {
+			return cached.vars
+		}
+	}
+
+	entry := &dotenvFile{}
+	if statErr != nil {
+		entry.err = statErr
+	} else {
+		entry.modTime, entry.size = info.ModTime(), info.Size()
+		data, err := os.ReadFile(path)
+		if err == nil {
+			entry.vars, err = parseDotenv(data)
+		}
+		entry.err = err
+	}
+	dotenvCache.files[path] = entry
+	if entry.err != nil {
+		log.Printf("WARN: Could not load .env file %s: %v", path, entry.err)
+		return nil
+	}
+	log.Printf("INFO: Loaded %d variable(s) from %s", len(entry.vars), path)
+	return entry.vars
+}
+
+// parseDotenv parses KEY=VALUE lines as written in .env files. Blank lines, # comments and an
+// "export " prefix are allowed. Double-quoted values may span lines and understand \n, \t, \"
+// and \\; single-quoted values are taken literally; unquoted values end at " #". /
+// parseDotenv mem-parse baris KEY=VALUE seperti yang ditulis di file .env. Baris kosong, komentar #
+// dan awalan "export " diperbolehkan. Nilai dengan kutip ganda boleh lebih dari satu baris dan
+// memahami \n, \t, \" dan \\; nilai dengan kutip tunggal diambil apa adanya; nilai tanpa kutip
+// berakhir pada " #".
+func parseDotenv(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNo)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, `"`):
+			// A double-quoted value continues until the closing quote, possibly on a later line.
+			// Nilai dengan kutip ganda berlanjut sampai kutip penutup, mungkin di baris berikutnya.
+			raw := value[1:]
+			start := lineNo
+			for !hasClosingQuote(raw) {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated quoted value", start)
+				}
+				lineNo++
+				raw += "\n" + scanner.Text()
+			}
+			value = unescapeDotenv(raw[:closingQuoteIndex(raw)])
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value", lineNo)
+			}
+			value = value[1 : end+1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		vars[key] = value
+	}
+	return vars, scanner.Err()
+}
+
+// closingQuoteIndex returns the index of the first unescaped double quote in s, or -1.
+// closingQuoteIndex mengembalikan index kutip ganda pertama yang tidak di-escape di s, atau -1.
+func closingQuoteIndex(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// hasClosingQuote reports whether s contains an unescaped double quote.
+// hasClosingQuote melaporkan apakah s berisi kutip ganda yang tidak di-escape.
+func hasClosingQuote(s string) bool {
+	return closingQuoteIndex(s) >= 0
+}
+
+// unescapeDotenv resolves the escape sequences of a double-quoted .env value.
+// unescapeDotenv menerjemahkan escape sequence dari nilai .env dengan kutip ganda.
+func unescapeDotenv(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(s)
+}
+
+// osVariables returns the OS environment variables selected by names. A name ending in "*"
+// selects every variable with that prefix; variables that are not set are left out. /
+// osVariables mengembalikan variabel environment OS yang dipilih oleh names. Nama yang berakhiran
+// "*" memilih semua variabel dengan awalan tersebut; variabel yang tidak di-set tidak disertakan.
+func osVariables(names []string) map[string]string {
+	vars := make(map[string]string)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			for _, kv := range os.Environ() {
+				if k, v, _ := strings.Cut(kv, "="); strings.HasPrefix(k, prefix) {
+					vars[k] = v
+				}
+			}
+		} else if v, ok := os.LookupEnv(name); ok && name != "" {
+			vars[name] = v
+		}
+	}
+	return vars
+}
+
+// linkedVariables returns the variables the environment takes from its .env files (later files
+// win) and the OS environment variables it imports, which win over the files. /
+// linkedVariables mengembalikan variabel yang diambil environment dari file .env-nya (file yang
+// belakangan menang) dan variabel environment OS yang di-import, yang menang atas file.
+func (e *Environment) linkedVariables() map[string]string {
+	vars := make(map[string]string)
+	for _, path := range e.DotenvFiles {
+		for k, v := range loadDotenvFile(path) {
+			vars[k] = v
+		}
+	}
+	for k, v := range osVariables(e.OSVariables) {
+		vars[k] = v
+	}
+	return vars
+}
+
+// allVariables returns a copy of the environment's variables merged over its linked variables,
+// so values set in panggil, by scripts or by captures win over .env files and the OS. /
+// allVariables mengembalikan salinan variabel environment yang digabung di atas variabel
+// tautannya, sehingga nilai yang di-set di panggil, oleh script atau capture menang atas file
+// .env dan OS.
+func (e *Environment) allVariables() map[string]string {
+	vars := e.linkedVariables()
+	for k, v := range e.Variables {
+		vars[k] = v
+	}
+	return vars
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "plain values, comments and export",
+			data: "# comment\n\nA=1\nexport B = two words\nC=x #trailing\nD=a#b\nE=\n",
+			want: map[string]string{"A": "1", "B": "two words", "C": "x", "D": "a#b", "E": ""},
+		},
+		{
+			name: "double quotes with escapes",
+			data: `A="line1\nline2" # note` + "\n" + `B="say \"hi\" \\ \t"`,
+			want: map[string]string{"A": "line1\nline2", "B": "say \"hi\" \\ \t"},
+		},
+		{
+			name: "multi-line double-quoted value",
+			data: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1\n",
+			want: map[string]string{"KEY": "-----BEGIN-----\nabc\n-----END-----", "NEXT": "1"},
+		},
+		{
+			name: "single quotes are literal",
+			data: `A='$HOME \n # not a comment'`,
+			want: map[string]string{"A": `$HOME \n # not a comment`},
+		},
+		{
+			name: "CRLF line endings",
+			data: "A=1\r\nB=\"2\"\r\n",
+			want: map[string]string{"A": "1", "B": "2"},
+		},
+		{name: "missing equals sign", data: "A=1\nJUSTAKEY\n", wantErr: true},
+		{name: "space in key", data: "MY KEY=1", wantErr: true},
+		{name: "empty key", data: "=1", wantErr: true},
+		{name: "unterminated double quote", data: "A=\"abc\nB=1\n", wantErr: true},
+		{name: "unterminated single quote", data: "A='abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotenv([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDotenv error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDotenv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironmentAllVariables(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, ".env")
+	second := filepath.Join(dir, ".env.local")
+	if err := os.WriteFile(first, []byte("HOST=file\nPORT=1\nUSER=file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(second, []byte("PORT=2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PANGGIL_TEST_USER", "os")
+	t.Setenv("PANGGIL_TEST_EXTRA", "extra")
+
+	env := &Environment{
+		Name:        "local",
+		Variables:   map[string]string{"HOST": "panggil"},
+		DotenvFiles: []string{first, second, filepath.Join(dir, "missing.env")},
+		OSVariables: []string{"PANGGIL_TEST_*", "PANGGIL_TEST_UNSET"},
+	}
+	want := map[string]string{
+		"HOST":               "panggil",
+		"PORT":               "2",
+		"USER":               "file",
+		"PANGGIL_TEST_USER":  "os",
+		"PANGGIL_TEST_EXTRA": "extra",
+	}
+	if got := env.allVariables(); !reflect.DeepEqual(got, want) {
+		t.Errorf("allVariables = %v, want %v", got, want)
+	}
+	if _, ok := env.Variables["PORT"]; ok {
+		t.Error("allVariables modified the environment's own variables")
+	}
+}
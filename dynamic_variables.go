@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/base64"
+	"math"
+	"math/rand/v2"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dynamicVariablePattern matches a built-in {{$name}} or {{$name(args)}} placeholder.
+// dynamicVariablePattern mencocokkan placeholder bawaan {{$name}} atau {{$name(args)}}.
+var dynamicVariablePattern = regexp.MustCompile(`\{\{\s*\$(\w+)(?:\(([^()]*)\))?\s*\}\}`)
+
+// randomStringChars are the characters used by {{$randomString(n)}}.
+// randomStringChars adalah karakter yang dipakai oleh {{$randomString(n)}}.
+const randomStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// maxRandomStringLength limits {{$randomString(n)}}, so a typo cannot allocate gigabytes.
+// maxRandomStringLength membatasi {{$randomString(n)}}, sehingga salah ketik tidak mengalokasikan gigabyte.
+const maxRandomStringLength = 1 << 20
+
+// isDynamicVariable reports whether name (the text between the braces) is a built-in dynamic variable.
+// isDynamicVariable melaporkan apakah name (teks di antara kurung kurawal) adalah variabel dinamis bawaan.
+func isDynamicVariable(name string) bool {
+	m := dynamicVariablePattern.FindStringSubmatch("{{" + name + "}}")
+	if m == nil {
+		return false
+	}
+	_, ok := evaluateDynamicVariable(m[1], m[2])
+	return ok
+}
+
+// expandDynamicVariables replaces built-in dynamic variables in text. Every occurrence is
+// evaluated on its own, so two {{$uuid}} placeholders get different values. Unknown names and
+// invalid arguments are left unchanged. /
+// expandDynamicVariables mengganti variabel dinamis bawaan dalam text. Setiap kemunculan
+// dievaluasi sendiri, sehingga dua placeholder {{$uuid}} mendapat nilai berbeda. Nama yang tidak
+// dikenal dan argumen yang tidak valid dibiarkan apa adanya.
+func expandDynamicVariables(text string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+	return dynamicVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := dynamicVariablePattern.FindStringSubmatch(match)
+		if value, ok := evaluateDynamicVariable(m[1], m[2]); ok {
+			return value
+		}
+		return match
+	})
+}
+
+// evaluateDynamicVariable returns a fresh value for the dynamic variable name called with args.
+// evaluateDynamicVariable mengembalikan nilai baru untuk variabel dinamis name yang dipanggil dengan args.
+func evaluateDynamicVariable(name, args string) (string, bool) {
+	switch name {
+	case "uuid", "guid":
+		return newUUID(), true
+	case "timestamp":
+		return strconv.FormatInt(time.Now().Unix(), 10), true
+	case "isoTimestamp":
+		return time.Now().UTC().Format(time.RFC3339), true
+	case "randomInt":
+		var low, high int64 = 0, 1000
+		if strings.TrimSpace(args) != "" {
+			parts := strings.Split(args, ",")
+			if len(parts) != 2 {
+				return "", false
+			}
+			var err1, err2 error
+			low, err1 = strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+			high, err2 = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+			if err1 != nil || err2 != nil || high < low {
+				return "", false
+			}
+		}
+		// The span is computed unsigned, so a range as wide as int64 does not overflow.
+		// Rentang dihitung tanpa tanda, sehingga rentang selebar int64 tidak overflow.
+		span := uint64(high) - uint64(low)
+		offset := rand.Uint64()
+		if span < math.MaxUint64 {
+			offset = rand.Uint64N(span + 1)
+		}
+		return strconv.FormatInt(low+int64(offset), 10), true
+	case "randomString":
+		n := 16
+		if strings.TrimSpace(args) != "" {
+			var err error
+			if n, err = strconv.Atoi(strings.TrimSpace(args)); err != nil || n < 0 || n > maxRandomStringLength {
+				return "", false
+			}
+		}
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = randomStringChars[rand.IntN(len(randomStringChars))]
+		}
+		return string(b), true
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(args)), true
+	case "env":
+		return os.Getenv(strings.TrimSpace(args)), true
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluateDynamicVariable(t *testing.T) {
+	t.Setenv("PANGGIL_TEST_HOME", "/home/panggil")
+
+	tests := []struct {
+		name    string
+		args    string
+		pattern string
+		wantOK  bool
+	}{
+		{name: "uuid", pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, wantOK: true},
+		{name: "guid", pattern: `^[0-9a-f-]{36}$`, wantOK: true},
+		{name: "timestamp", pattern: `^\d{10,}$`, wantOK: true},
+		{name: "isoTimestamp", pattern: `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`, wantOK: true},
+		{name: "randomInt", pattern: `^\d{1,4}$`, wantOK: true},
+		{name: "randomInt", args: "5, 5", pattern: `^5$`, wantOK: true},
+		{name: "randomInt", args: "-3,-3", pattern: `^-3$`, wantOK: true},
+		{name: "randomInt", args: "-9223372036854775808,9223372036854775807", pattern: `^-?\d+$`, wantOK: true},
+		{name: "randomInt", args: "10,1"},
+		{name: "randomInt", args: "1"},
+		{name: "randomInt", args: "a,b"},
+		{name: "randomString", pattern: `^[a-zA-Z0-9]{16}$`, wantOK: true},
+		{name: "randomString", args: "4", pattern: `^[a-zA-Z0-9]{4}$`, wantOK: true},
+		{name: "randomString", args: "0", pattern: `^$`, wantOK: true},
+		{name: "randomString", args: "-1"},
+		{name: "randomString", args: strconv.Itoa(maxRandomStringLength + 1)},
+		{name: "base64", args: "user:pass", pattern: "^" + regexp.QuoteMeta(base64.StdEncoding.EncodeToString([]byte("user:pass"))) + "$", wantOK: true},
+		{name: "env", args: " PANGGIL_TEST_HOME ", pattern: `^/home/panggil$`, wantOK: true},
+		{name: "unknown"},
+	}
+	for _, tt := range tests {
+		got, ok := evaluateDynamicVariable(tt.name, tt.args)
+		if ok != tt.wantOK {
+			t.Errorf("$%s(%s) ok = %v, want %v", tt.name, tt.args, ok, tt.wantOK)
+			continue
+		}
+		if ok && !regexp.MustCompile(tt.pattern).MatchString(got) {
+			t.Errorf("$%s(%s) = %q, want a match for %s", tt.name, tt.args, got, tt.pattern)
+		}
+	}
+}
+
+func TestRandomIntStaysInRange(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		got, ok := evaluateDynamicVariable("randomInt", "1,3")
+		n, err := strconv.Atoi(got)
+		if !ok || err != nil || n < 1 || n > 3 {
+			t.Fatalf("$randomInt(1,3) = %q", got)
+		}
+	}
+}
+
+func TestExpandDynamicVariables(t *testing.T) {
+	tests := []struct {
+		text    string
+		pattern string
+	}{
+		{text: "no placeholders", pattern: `^no placeholders$`},
+		{text: "{{baseUrl}}/users", pattern: `^\{\{baseUrl\}\}/users$`},
+		{text: "id={{$randomInt(7,7)}}&n={{ $randomString(3) }}", pattern: `^id=7&n=[a-zA-Z0-9]{3}$`},
+		{text: "{{$unknown}} {{$randomInt(9,1)}}", pattern: `^\{\{\$unknown\}\} \{\{\$randomInt\(9,1\)\}\}$`},
+		{text: "Basic {{$base64(a:b)}}", pattern: `^Basic YTpi$`},
+	}
+	for _, tt := range tests {
+		if got := expandDynamicVariables(tt.text); !regexp.MustCompile(tt.pattern).MatchString(got) {
+			t.Errorf("expandDynamicVariables(%q) = %q, want a match for %s", tt.text, got, tt.pattern)
+		}
+	}
+
+	// Every occurrence is evaluated on its own.
+	got := strings.Split(expandDynamicVariables("{{$uuid}} {{$uuid}}"), " ")
+	if len(got) != 2 || got[0] == got[1] {
+		t.Errorf("two {{$uuid}} placeholders expanded to %q", got)
+	}
+
+	before := time.Now().Unix()
+	stamp, _ := strconv.ParseInt(expandDynamicVariables("{{$timestamp}}"), 10, 64)
+	if stamp < before || stamp > time.Now().Unix() {
+		t.Errorf("{{$timestamp}} = %d, want the current time", stamp)
+	}
+}
+
+func TestIsDynamicVariable(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"$uuid", true},
+		{" $randomInt(1,2) ", true},
+		{"$randomInt(2,1)", false},
+		{"$nope", false},
+		{"baseUrl", false},
+	}
+	for _, tt := range tests {
+		if got := isDynamicVariable(tt.name); got != tt.want {
+			t.Errorf("isDynamicVariable(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
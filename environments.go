@@ -0,0 +1,574 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// setActiveEnvironment makes the environment at index active and saves the choice.
+// setActiveEnvironment menjadikan environment pada index sebagai yang aktif dan menyimpan pilihan tersebut.
+func (a *App) setActiveEnvironment(index int) {
+	if index < 0 || index >= len(a.environments) || index == a.activeEnvIndex {
+		return
+	}
+	a.activeEnvIndex = index
+	a.saveEnvironments()
+	a.refreshEnvDropdown()
+	log.Printf("INFO: Active environment is now '%s'", a.environments[index].Name)
+}
+
+// createEnvironment adds an empty environment and returns its index.
+// createEnvironment menambahkan environment kosong dan mengembalikan index-nya.
+func (a *App) createEnvironment(name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if err := a.validateEnvironmentName(name, nil); err != nil {
+		return -1, err
+	}
+	a.environments = append(a.environments, &Environment{Name: name, Variables: make(map[string]string)})
+	a.saveEnvironments()
+	a.refreshEnvDropdown()
+	return len(a.environments) - 1, nil
+}
+
+// cloneEnvironment copies the environment at index, including its variables, under a new name.
+// cloneEnvironment menyalin environment pada index, termasuk variabelnya, dengan nama baru.
+func (a *App) cloneEnvironment(index int, name string) (int, error) {
+	name = strings.TrimSpace(name)
+	if err := a.validateEnvironmentName(name, nil); err != nil {
+		return -1, err
+	}
+	clone := &Environment{Name: name, Variables: make(map[string]string)}
+	for k, v := range a.environments[index].Variables {
+		clone.Variables[k] = v
+	}
+	clone.Secrets = append([]string(nil), a.environments[index].Secrets...)
+	clone.DotenvFiles = append([]string(nil), a.environments[index].DotenvFiles...)
+	clone.OSVariables = append([]string(nil), a.environments[index].OSVariables...)
+	for k, cv := range a.environments[index].Commands {
+		if clone.Commands == nil {
+			clone.Commands = make(map[string]*CommandVariable)
+		}
+		copied := *cv
+		clone.Commands[k] = &copied
+	}
+	a.environments = append(a.environments, clone)
+	a.saveEnvironments()
+	a.refreshEnvDropdown()
+	return len(a.environments) - 1, nil
+}
+
+// renameEnvironment changes the name of the environment at index.
+// renameEnvironment mengubah nama environment pada index.
+func (a *App) renameEnvironment(index int, name string) error {
+	name = strings.TrimSpace(name)
+	env := a.environments[index]
+	if err := a.validateEnvironmentName(name, env); err != nil {
+		return err
+	}
+	env.Name = name
+	a.saveEnvironments()
+	a.refreshEnvDropdown()
+	return nil
+}
+
+// deleteEnvironment removes the environment at index. The last environment cannot be deleted.
+// deleteEnvironment menghapus environment pada index. Environment terakhir tidak dapat dihapus.
+func (a *App) deleteEnvironment(index int) error {
+	if len(a.environments) <= 1 {
+		return fmt.Errorf("the last environment cannot be deleted")
+	}
+	a.environments = append(a.environments[:index], a.environments[index+1:]...)
+	if a.activeEnvIndex > index || a.activeEnvIndex >= len(a.environments) {
+		a.activeEnvIndex--
+	}
+	a.saveEnvironments()
+	a.refreshEnvDropdown()
+	return nil
+}
+
+// validateEnvironmentName checks that name is not empty and not used by another environment.
+// validateEnvironmentName memeriksa bahwa name tidak kosong dan tidak dipakai environment lain.
+func (a *App) validateEnvironmentName(name string, self *Environment) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if existing := a.environmentByName(name); existing != nil && existing != self {
+		return fmt.Errorf("environment '%s' already exists", existing.Name)
+	}
+	return nil
+}
+
+// refreshEnvDropdown updates the header-bar environment dropdown after environments change.
+// refreshEnvDropdown memperbarui dropdown environment di header bar setelah environments berubah.
+func (a *App) refreshEnvDropdown() {
+	if a.envDropdown == nil {
+		return
+	}
+	names := make([]string, len(a.environments))
+	for i, env := range a.environments {
+		names[i] = env.Name
+	}
+	a.envDropdown.SetOptions(names, func(text string, index int) {
+		a.setActiveEnvironment(index)
+	})
+	a.envDropdown.SetCurrentOption(a.activeEnvIndex)
+}
+
+// showEnvironmentModal manages environments (left) and the variables of the selected
+// environment (right). Enter on an environment makes it active. /
+// showEnvironmentModal mengelola environments (kiri) dan variabel dari environment yang dipilih
+// (kanan). Enter pada sebuah environment menjadikannya aktif.
+func (a *App) showEnvironmentModal() {
+	envList := tview.NewList().ShowSecondaryText(false)
+	envList.SetBorder(true).SetTitle(" Environments ")
+	varList := tview.NewList().ShowSecondaryText(true)
+	varList.SetBorder(true).SetTitle(" Variables ")
+
+	selected := func() *Environment {
+		index := envList.GetCurrentItem()
+		if index < 0 || index >= len(a.environments) {
+			return nil
+		}
+		return a.environments[index]
+	}
+
+	refreshVars := func() {
+		varList.Clear()
+		env := selected()
+		if env == nil {
+			return
+		}
+		keys := make([]string, 0, len(env.Variables))
+		for k := range env.Variables {
+			if env.Commands[k] == nil {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			displayValue := env.Variables[key]
+			if len(displayValue) > 30 {
+				displayValue = displayValue[:27] + "..."
+			}
+			if env.isSecret(key) {
+				if !a.revealSecrets {
+					displayValue = secretMask
+				}
+				displayValue = "🔒 " + displayValue
+			}
+			varList.AddItem(key, displayValue, 0, nil)
+		}
+		keys = keys[:0]
+		for k := range env.Commands {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			displayValue := commandPlaceholder(env.Commands[key])
+			if len(displayValue) > 30 {
+				displayValue = displayValue[:27] + "..."
+			}
+			varList.AddItem(key, "[cyan]"+tview.Escape(displayValue)+"[-]", 0, nil)
+		}
+		// Linked variables are listed after the environment's own; editing one overrides it.
+		// Variabel tautan ditampilkan setelah variabel milik environment; mengeditnya akan menimpanya.
+		linked := env.linkedVariables()
+		keys = keys[:0]
+		for k := range linked {
+			if _, ok := env.Variables[k]; !ok && env.Commands[k] == nil {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			displayValue := linked[key]
+			if len(displayValue) > 30 {
+				displayValue = displayValue[:27] + "..."
+			}
+			varList.AddItem(key, "[gray]"+tview.Escape(displayValue)+" (linked)[-]", 0, nil)
+		}
+		if varList.GetItemCount() == 0 {
+			varList.AddItem("[gray]No variables", "Press 'a' to add", 0, nil)
+		}
+		varList.SetTitle(fmt.Sprintf(" Variables: %s ", env.Name))
+	}
+
+	refreshEnvs := func(current int) {
+		envList.Clear()
+		for i, env := range a.environments {
+			label := "  " + env.Name
+			if i == a.activeEnvIndex {
+				label = "[green]● " + env.Name + "[-]"
+			}
+			envList.AddItem(label, "", 0, nil)
+		}
+		if current >= envList.GetItemCount() {
+			current = envList.GetItemCount() - 1
+		}
+		if current >= 0 {
+			envList.SetCurrentItem(current)
+		}
+		refreshVars()
+	}
+	envList.SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		refreshVars()
+	})
+	envList.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		a.setActiveEnvironment(index)
+		refreshEnvs(index)
+	})
+	refreshEnvs(a.activeEnvIndex)
+
+	// Variable changes are saved right away.
+	// Perubahan variabel langsung disimpan.
+	onVarsChanged := func() {
+		a.saveEnvironments()
+		refreshVars()
+		a.app.SetFocus(varList)
+	}
+	selectedKey := func() string {
+		if varList.GetItemCount() == 0 {
+			return ""
+		}
+		key, _ := varList.GetItemText(varList.GetCurrentItem())
+		if key == "[gray]No variables" {
+			return ""
+		}
+		return key
+	}
+
+	closeModal := func() {
+		a.rootPages.RemovePage("envModal")
+	}
+
+	envList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		index := envList.GetCurrentItem()
+		switch event.Rune() {
+		case 'n':
+			a.showEnvironmentNameModal(" New Environment ", "", envList, func(name string) error {
+				created, err := a.createEnvironment(name)
+				if err == nil {
+					refreshEnvs(created)
+				}
+				return err
+			})
+			return nil
+		case 'c':
+			source := a.environments[index]
+			a.showEnvironmentNameModal(" Clone Environment ", source.Name+" copy", envList, func(name string) error {
+				created, err := a.cloneEnvironment(index, name)
+				if err == nil {
+					refreshEnvs(created)
+				}
+				return err
+			})
+			return nil
+		case 'r':
+			a.showEnvironmentNameModal(" Rename Environment ", a.environments[index].Name, envList, func(name string) error {
+				err := a.renameEnvironment(index, name)
+				if err == nil {
+					refreshEnvs(index)
+				}
+				return err
+			})
+			return nil
+		case 'd':
+			a.confirmDeleteEnvironment(index, envList, func() { refreshEnvs(index) })
+			return nil
+		case 'l':
+			a.showLinkedSourcesModal(a.environments[index], envList, func() { refreshEnvs(index) })
+			return nil
+		}
+		switch event.Key() {
+		case tcell.KeyDelete:
+			a.confirmDeleteEnvironment(index, envList, func() { refreshEnvs(index) })
+			return nil
+		case tcell.KeyTab, tcell.KeyRight:
+			a.app.SetFocus(varList)
+			return nil
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		}
+		return event
+	})
+
+	varList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		env := selected()
+		if env == nil {
+			return event
+		}
+		if env.Variables == nil {
+			env.Variables = make(map[string]string)
+		}
+		switch event.Rune() {
+		case 'a':
+			a.showAddVariableModal(env, onVarsChanged)
+			return nil
+		case 'c':
+			a.showCommandVariableModal(env, "", varList, onVarsChanged)
+			return nil
+		case 'e':
+			if key := selectedKey(); key != "" && env.Commands[key] != nil {
+				a.showCommandVariableModal(env, key, varList, onVarsChanged)
+			} else if key != "" {
+				a.showEditVariableModal(env, key, onVarsChanged)
+			}
+			return nil
+		case 'd':
+			if key := selectedKey(); key != "" {
+				if env.Commands[key] != nil {
+					delete(env.Commands, key)
+				} else {
+					delete(env.Variables, key)
+					env.setSecret(key, false)
+				}
+				onVarsChanged()
+			}
+			return nil
+		case 's':
+			if key := selectedKey(); key != "" {
+				current := varList.GetCurrentItem()
+				env.setSecret(key, !env.isSecret(key))
+				onVarsChanged()
+				varList.SetCurrentItem(current)
+			}
+			return nil
+		case 'v':
+			current := varList.GetCurrentItem()
+			a.toggleRevealSecrets()
+			refreshVars()
+			varList.SetCurrentItem(current)
+			return nil
+		}
+		switch event.Key() {
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyLeft:
+			a.app.SetFocus(envList)
+			return nil
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView().SetDynamicColors(true).
+		SetText("[yellow]Environments:[-] Enter activate  n new  c clone  r rename  d delete  l link .env/OS   [yellow]Variables:[-] a add  c command  e edit  d delete  s secret  v reveal   [yellow]Tab[-] switch  [yellow]Esc[-] close")
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(envList, 30, 0, true).
+			AddItem(varList, 0, 1, false), 0, 1, true).
+		AddItem(help, 2, 0, false)
+	content.SetBorder(true).SetTitle(" Environments (F10) ")
+
+	modal := a.createModal(content, 100, 24)
+	a.rootPages.AddPage("envModal", modal, true, true)
+	a.app.SetFocus(envList)
+}
+
+// showEnvironmentNameModal asks for an environment name. onSave reports a validation error,
+// which is shown in the form title. /
+// showEnvironmentNameModal meminta nama environment. onSave melaporkan error validasi, yang
+// ditampilkan di judul form.
+func (a *App) showEnvironmentNameModal(title, initial string, returnFocus tview.Primitive, onSave func(name string) error) {
+	nameInput := tview.NewInputField().SetLabel("Name").SetText(initial).SetFieldWidth(30)
+	closeModal := func() {
+		a.rootPages.RemovePage("envNameModal")
+		a.app.SetFocus(returnFocus)
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(nameInput).
+		AddButton("Save", func() {
+			if err := onSave(nameInput.GetText()); err != nil {
+				form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+				return
+			}
+			closeModal()
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+	form.SetBorder(true).SetTitle(title)
+
+	modal := a.createModal(form, 50, 7)
+	a.rootPages.AddPage("envNameModal", modal, true, true)
+	a.app.SetFocus(nameInput)
+}
+
+// showLinkedSourcesModal edits the .env files linked to env and the OS variables it imports,
+// both as comma-separated lists. /
+// showLinkedSourcesModal mengedit file .env yang ditautkan ke env dan variabel OS yang
+// di-import-nya, keduanya sebagai daftar yang dipisahkan koma.
+func (a *App) showLinkedSourcesModal(env *Environment, returnFocus tview.Primitive, onSave func()) {
+	filesInput := tview.NewInputField().SetLabel(".env files").SetFieldWidth(50).
+		SetText(strings.Join(env.DotenvFiles, ", ")).
+		SetPlaceholder(".env, .env.local")
+	osInput := tview.NewInputField().SetLabel("OS variables").SetFieldWidth(50).
+		SetText(strings.Join(env.OSVariables, ", ")).
+		SetPlaceholder("DATABASE_URL, AWS_*")
+	closeModal := func() {
+		a.rootPages.RemovePage("envLinkModal")
+		a.app.SetFocus(returnFocus)
+	}
+	splitList := func(text string) []string {
+		var items []string
+		for _, item := range strings.Split(text, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(filesInput).
+		AddFormItem(osInput).
+		AddButton("Save", func() {
+			files := splitList(filesInput.GetText())
+			for _, path := range files {
+				if _, err := os.Stat(resolveDotenvPath(path)); err != nil {
+					form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+					return
+				}
+			}
+			env.DotenvFiles = files
+			env.OSVariables = splitList(osInput.GetText())
+			a.saveEnvironments()
+			closeModal()
+			onSave()
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Linked Variables: %s ", env.Name))
+
+	modal := a.createModal(form, 70, 9)
+	a.rootPages.AddPage("envLinkModal", modal, true, true)
+	a.app.SetFocus(filesInput)
+}
+
+// showCommandVariableModal adds a command variable to env, or edits the one called name. Test
+// runs the command in the background and shows the result in the form title. /
+// showCommandVariableModal menambahkan variabel perintah ke env, atau mengedit yang bernama name.
+// Test menjalankan perintah di background dan menampilkan hasilnya di judul form.
+func (a *App) showCommandVariableModal(env *Environment, name string, returnFocus tview.Primitive, onSave func()) {
+	cv := &CommandVariable{}
+	if existing := env.Commands[name]; existing != nil {
+		cv = existing
+	}
+	nameInput := tview.NewInputField().SetLabel("Name").SetText(name).SetFieldWidth(30)
+	commandInput := tview.NewInputField().SetLabel("Command").SetText(cv.Command).SetFieldWidth(50).
+		SetPlaceholder("gcloud auth print-access-token")
+	secondsText := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	cacheInput := tview.NewInputField().SetLabel("Cache (s)").SetText(secondsText(cv.CacheSeconds)).
+		SetFieldWidth(8).SetAcceptanceFunc(tview.InputFieldInteger).SetPlaceholder("0")
+	timeoutInput := tview.NewInputField().SetLabel("Timeout (s)").SetText(secondsText(cv.TimeoutSeconds)).
+		SetFieldWidth(8).SetAcceptanceFunc(tview.InputFieldInteger).SetPlaceholder("10")
+	closeModal := func() {
+		a.rootPages.RemovePage("commandVarModal")
+		a.app.SetFocus(returnFocus)
+	}
+	current := func() *CommandVariable {
+		cacheSeconds, _ := strconv.Atoi(cacheInput.GetText())
+		timeoutSeconds, _ := strconv.Atoi(timeoutInput.GetText())
+		return &CommandVariable{
+			Command:        strings.TrimSpace(commandInput.GetText()),
+			CacheSeconds:   max(cacheSeconds, 0),
+			TimeoutSeconds: max(timeoutSeconds, 0),
+		}
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(nameInput).
+		AddFormItem(commandInput).
+		AddFormItem(cacheInput).
+		AddFormItem(timeoutInput).
+		AddButton("Save", func() {
+			newName := strings.TrimSpace(nameInput.GetText())
+			updated := current()
+			if newName == "" || updated.Command == "" {
+				form.SetTitle(" [red]name and command are required ")
+				return
+			}
+			if env.Commands == nil {
+				env.Commands = make(map[string]*CommandVariable)
+			}
+			delete(env.Commands, name)
+			// A command variable replaces a plain variable of the same name.
+			// Variabel perintah menggantikan variabel biasa dengan nama yang sama.
+			delete(env.Variables, newName)
+			env.setSecret(newName, false)
+			env.Commands[newName] = updated
+			// A command typed here is the user's own, so it may run in this workspace.
+			// Perintah yang diketik di sini adalah milik pengguna, sehingga boleh dijalankan di workspace ini.
+			trustCommands(updated.Command)
+			closeModal()
+			onSave()
+		}).
+		AddButton("Test", func() {
+			updated := current()
+			if updated.Command == "" {
+				return
+			}
+			updated.CacheSeconds = 0
+			trustCommands(updated.Command)
+			form.SetTitle(" [yellow]Running... ")
+			go func() {
+				value, err := runCommandVariable(updated)
+				a.app.QueueUpdateDraw(func() {
+					if err != nil {
+						form.SetTitle(fmt.Sprintf(" [red]%s ", tview.Escape(err.Error())))
+						return
+					}
+					if len(value) > 40 {
+						value = value[:37] + "..."
+					}
+					form.SetTitle(fmt.Sprintf(" [green]Output:[-] %s ", tview.Escape(value)))
+				})
+			}()
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+	form.SetBorder(true).SetTitle(" Command Variable ")
+
+	modal := a.createModal(form, 70, 13)
+	a.rootPages.AddPage("commandVarModal", modal, true, true)
+	a.app.SetFocus(nameInput)
+}
+
+// confirmDeleteEnvironment asks before deleting the environment at index.
+// confirmDeleteEnvironment meminta konfirmasi sebelum menghapus environment pada index.
+func (a *App) confirmDeleteEnvironment(index int, returnFocus tview.Primitive, onDeleted func()) {
+	if len(a.environments) <= 1 {
+		return
+	}
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete environment '%s' and all of its variables?", a.environments[index].Name)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.rootPages.RemovePage("envDeleteModal")
+			a.app.SetFocus(returnFocus)
+			if buttonLabel == "Delete" {
+				if err := a.deleteEnvironment(index); err != nil {
+					log.Printf("ERROR: %v", err)
+					return
+				}
+				onDeleted()
+			}
+		})
+	a.rootPages.AddPage("envDeleteModal", modal, true, true)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// exportFormats lists the formats a collection node can be exported to, in menu order.
+// exportFormats berisi daftar format yang dapat digunakan untuk meng-export sebuah node collection, sesuai urutan menu.
+var exportFormats = []string{"Postman v2.1", "HTTP file (.http)"}
+
+// exportCollection converts a collection node into the requested export format. Secret auth
+// credentials are left out. /
+// exportCollection mengkonversi sebuah node collection ke format export yang diminta. Kredensial
+// auth rahasia tidak disertakan.
+func exportCollection(node *CollectionNode, format string) ([]byte, error) {
+	node = redactCollection(node)
+	switch format {
+	case "Postman v2.1":
+		return exportPostmanCollection(node)
+	case "HTTP file (.http)":
+		return exportHTTPFile(node, nil)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// exportCollectionToFile exports a collection node and writes it to path.
+// exportCollectionToFile meng-export sebuah node collection dan menulisnya ke path.
+func exportCollectionToFile(node *CollectionNode, format, path string) error {
+	data, err := exportCollection(node, format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+	return nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// defaultExportFileName suggests a file name for exporting node in the given format.
+// defaultExportFileName menyarankan nama file untuk meng-export node dalam format tertentu.
+func defaultExportFileName(node *CollectionNode, format string) string {
+	base := strings.Trim(unsafeFileChars.ReplaceAllString(node.Name, "_"), "_")
+	if base == "" {
+		base = "collection"
+	}
+	switch format {
+	case "Postman v2.1":
+		return base + ".postman_collection.json"
+	case "HTTP file (.http)":
+		return base + ".http"
+	default:
+		return base + ".json"
+	}
+}
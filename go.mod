@@ -4,18 +4,23 @@ go 1.25.1
 
 require (
 	github.com/atotto/clipboard v0.1.4
+	github.com/dop251/goja v0.0.0-20260917113740-793a2a65c13b
 	github.com/gdamore/tcell/v2 v2.9.0
 	github.com/jhump/protoreflect v1.17.0
 	github.com/rivo/tview v0.42.0
 	github.com/sahilm/fuzzy v0.1.1
 	google.golang.org/grpc v1.76.0
 	google.golang.org/protobuf v1.36.6
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/bufbuild/protocompile v0.14.1 // indirect
+	github.com/dlclark/regexp2/v2 v2.5.2 // indirect
 	github.com/gdamore/encoding v1.0.1 // indirect
+	github.com/go-sourcemap/sourcemap v2.1.3+incompatible // indirect
 	github.com/golang/protobuf v1.5.4 // indirect
+	github.com/google/pprof v0.0.0-20230207041349-798e818bf904 // indirect
 	github.com/kylelemons/godebug v1.1.0 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// GrpcRequestData contains all the information needed to invoke a gRPC method.
+// GrpcRequestData berisi semua informasi yang dibutuhkan untuk memanggil sebuah method gRPC.
+type GrpcRequestData struct {
+	Server   string
+	Method   string // Full method name in "package.Service/Method" form / Nama method lengkap dalam format "package.Service/Method"
+	Metadata map[string]string
+	Body     string
+}
+
+// GrpcResponseData contains the results of a gRPC invocation.
+// GrpcResponseData berisi hasil dari pemanggilan gRPC.
+type GrpcResponseData struct {
+	Body     []byte // Response message marshaled as indented JSON / Pesan response yang di-marshal sebagai JSON ter-indentasi
+	Duration time.Duration
+	Error    error
+}
+
+// grpcSession holds a connection and reflection client for a single server.
+// grpcSession menyimpan koneksi dan client reflection untuk satu server.
+type grpcSession struct {
+	conn          *grpc.ClientConn
+	reflectClient *grpcreflect.Client
+	stub          grpcdynamic.Stub
+}
+
+// grpcSessionPool reuses gRPC connections by server address so that running many
+// requests against the same server does not dial for every call. /
+// grpcSessionPool menggunakan ulang koneksi gRPC berdasarkan alamat server agar menjalankan banyak
+// request ke server yang sama tidak melakukan dial untuk setiap panggilan.
+type grpcSessionPool struct {
+	sessions map[string]*grpcSession
+}
+
+// newGrpcSessionPool creates an empty session pool.
+// newGrpcSessionPool membuat session pool yang kosong.
+func newGrpcSessionPool() *grpcSessionPool {
+	return &grpcSessionPool{sessions: make(map[string]*grpcSession)}
+}
+
+// get returns the session for a server, dialing it on first use.
+// get mengembalikan session untuk sebuah server, melakukan dial pada penggunaan pertama.
+func (p *grpcSessionPool) get(server string) (*grpcSession, error) {
+	if s, ok := p.sessions[server]; ok {
+		return s, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), settings.grpcDialTimeout())
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, server,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		log.Printf("ERROR: gRPC dial failed for %s: %v", server, err)
+		return nil, fmt.Errorf("connecting to %s: %w", server, err)
+	}
+
+	s := &grpcSession{
+		conn:          conn,
+		reflectClient: grpcreflect.NewClientAuto(context.Background(), conn),
+		stub:          grpcdynamic.NewStub(conn),
+	}
+	p.sessions[server] = s
+	return s, nil
+}
+
+// Close closes every connection held by the pool.
+// Close menutup semua koneksi yang disimpan oleh pool.
+func (p *grpcSessionPool) Close() {
+	for server, s := range p.sessions {
+		s.reflectClient.Reset()
+		s.conn.Close()
+		delete(p.sessions, server)
+	}
+}
+
+// doGrpcRequest invokes a gRPC method through server reflection and returns the result.
+// Like doHttpRequest, it has no dependency on the UI (tview). /
+// doGrpcRequest memanggil sebuah method gRPC melalui server reflection dan mengembalikan hasilnya.
+// Seperti doHttpRequest, fungsi ini tidak memiliki dependensi ke UI (tview).
+func doGrpcRequest(pool *grpcSessionPool, data GrpcRequestData) *GrpcResponseData {
+	session, err := pool.get(data.Server)
+	if err != nil {
+		return &GrpcResponseData{Error: err}
+	}
+	return invokeGrpc(session, data)
+}
+
+// invokeGrpc invokes a method over an already established session.
+// invokeGrpc memanggil sebuah method melalui session yang sudah terhubung.
+func invokeGrpc(session *grpcSession, data GrpcRequestData) *GrpcResponseData {
+	parts := strings.SplitN(data.Method, "/", 2)
+	if len(parts) != 2 {
+		return &GrpcResponseData{Error: fmt.Errorf("invalid service/method format: %s", data.Method)}
+	}
+	serviceName, methodName := parts[0], parts[1]
+
+	sd, err := session.reflectClient.ResolveService(serviceName)
+	if err != nil {
+		log.Printf("ERROR: Failed to resolve gRPC service '%s': %v", serviceName, err)
+		return &GrpcResponseData{Error: fmt.Errorf("resolving service '%s': %w", serviceName, err)}
+	}
+	md := sd.FindMethodByName(methodName)
+	if md == nil {
+		return &GrpcResponseData{Error: fmt.Errorf("method '%s' not found in service '%s'", methodName, serviceName)}
+	}
+
+	dynMsg := dynamic.NewMessage(md.GetInputType())
+	if strings.TrimSpace(data.Body) != "" {
+		if err := dynMsg.UnmarshalJSON([]byte(data.Body)); err != nil {
+			return &GrpcResponseData{Error: fmt.Errorf("parsing request body JSON: %w", err)}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), settings.grpcCallTimeout())
+	defer cancel()
+	if len(data.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(data.Metadata))
+	}
+
+	log.Printf("INFO: Invoking gRPC method: %s on %s", data.Method, data.Server)
+	start := time.Now()
+	resp, err := session.stub.InvokeRpc(ctx, md, dynMsg)
+	duration := time.Since(start)
+	if err != nil {
+		log.Printf("ERROR: gRPC InvokeRpc failed for %s: %v", data.Method, err)
+		return &GrpcResponseData{Error: err, Duration: duration}
+	}
+
+	dynResp, ok := resp.(*dynamic.Message)
+	if !ok {
+		return &GrpcResponseData{Error: fmt.Errorf("unexpected response type %T", resp), Duration: duration}
+	}
+	respJSON, err := dynResp.MarshalJSONIndent()
+	if err != nil {
+		return &GrpcResponseData{Error: fmt.Errorf("formatting response JSON: %w", err), Duration: duration}
+	}
+
+	log.Printf("INFO: gRPC call to %s successful. Duration: %v", data.Method, duration)
+	return &GrpcResponseData{Body: respJSON, Duration: duration}
+}
+
+// parseGrpcMetadata parses a JSON object of metadata into a string map.
+// parseGrpcMetadata mem-parse object JSON metadata menjadi map string.
+func parseGrpcMetadata(text string) (map[string]string, error) {
+	metaMap := make(map[string]string)
+	if strings.TrimSpace(text) == "" {
+		return metaMap, nil
+	}
+	if err := json.Unmarshal([]byte(text), &metaMap); err != nil {
+		return nil, fmt.Errorf("parsing metadata JSON: %w", err)
+	}
+	return metaMap, nil
+}
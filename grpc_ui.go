@@ -162,6 +162,7 @@ func (a *App) hideMethodList() {
 func (a *App) selectGrpcMethod(methodName string) {
 	if a.grpcCurrentService != "" && a.grpcCurrentService != methodName {
 		a.grpcBodyCache[a.grpcCurrentService] = a.grpcRequestBody.GetText()
+		a.saveGrpcCache()
 	}
 	a.grpcCurrentService = methodName
 	a.grpcMethodInput.SetText(methodName)
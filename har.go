@@ -0,0 +1,325 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// HAR 1.2 structures (http://www.softwareishard.com/blog/har-12-spec/).
+// Struktur HAR 1.2 (http://www.softwareishard.com/blog/har-12-spec/).
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Pages   []harPage  `json:"pages,omitempty"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	Title string `json:"title"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string         `json:"mimeType"`
+	Text     string         `json:"text"`
+	Params   []harNameValue `json:"params,omitempty"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// isHAR reports whether data is a HAR file.
+// isHAR melaporkan apakah data merupakan file HAR.
+func isHAR(data []byte) bool {
+	var probe struct {
+		Log struct {
+			Version string          `json:"version"`
+			Entries json.RawMessage `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.Log.Entries != nil
+}
+
+// importHAR converts every entry of a HAR file into a request in a folder called name.
+// Pseudo-headers and headers that are computed when sending are dropped. /
+// importHAR mengkonversi setiap entri file HAR menjadi request di dalam folder bernama name.
+// Pseudo-header dan header yang dihitung saat mengirim tidak disertakan.
+func importHAR(data []byte, name string) (*ImportResult, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("parsing HAR file: %w", err)
+	}
+	if len(har.Log.Pages) > 0 && har.Log.Pages[0].Title != "" {
+		name = har.Log.Pages[0].Title
+	}
+
+	root := &CollectionNode{Name: name, IsFolder: true}
+	var warnings []string
+	for i, entry := range har.Log.Entries {
+		req := &Request{
+			Type:    "http",
+			Method:  strings.ToUpper(entry.Request.Method),
+			URL:     entry.Request.URL,
+			Headers: make(map[string]string),
+			Time:    time.Now(),
+		}
+		if req.Method == "" {
+			req.Method = "GET"
+		}
+		req.Name = req.Method + " " + harRequestLabel(entry.Request.URL)
+
+		for _, h := range entry.Request.Headers {
+			if strings.HasPrefix(h.Name, ":") || isComputedHeader(h.Name) {
+				continue
+			}
+			if existing, ok := req.Headers[h.Name]; ok {
+				req.Headers[h.Name] = existing + ", " + h.Value
+				continue
+			}
+			req.Headers[h.Name] = h.Value
+		}
+
+		if pd := entry.Request.PostData; pd != nil {
+			switch {
+			case pd.Text != "":
+				req.Body = pd.Text
+			case len(pd.Params) > 0:
+				values := url.Values{}
+				for _, p := range pd.Params {
+					values.Add(p.Name, p.Value)
+				}
+				req.Body = values.Encode()
+			}
+			if pd.MimeType != "" && !headersHave(req.Headers, "Content-Type") {
+				req.Headers["Content-Type"] = pd.MimeType
+			}
+			if strings.HasPrefix(pd.MimeType, "multipart/") {
+				warnings = append(warnings, fmt.Sprintf("entry %d (%s): multipart body may contain binary parts that were not captured", i+1, req.Name))
+			}
+		}
+
+		if len(req.Headers) > 0 {
+			raw, _ := json.MarshalIndent(req.Headers, "", "  ")
+			req.HeadersRaw = string(raw)
+		}
+		root.Children = append(root.Children, &CollectionNode{Name: req.Name, Request: req})
+	}
+	return &ImportResult{Collection: root, Warnings: warnings}, nil
+}
+
+// harRequestLabel shortens a URL to its host and path for use as a request name.
+// harRequestLabel mempersingkat URL menjadi host dan path-nya untuk digunakan sebagai nama request.
+func harRequestLabel(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host + u.Path
+}
+
+// isComputedHeader reports headers that the HTTP client sets itself when sending.
+// isComputedHeader melaporkan header yang diatur sendiri oleh HTTP client saat mengirim.
+func isComputedHeader(name string) bool {
+	switch strings.ToLower(name) {
+	case "content-length", "host", "connection", "accept-encoding":
+		return true
+	}
+	return false
+}
+
+// exportHAR writes HTTP history entries, including their responses and timings, as a HAR file.
+// gRPC entries cannot be represented in HAR and are skipped. /
+// exportHAR menulis entri History HTTP, termasuk response dan waktunya, sebagai file HAR.
+// Entri gRPC tidak dapat direpresentasikan dalam HAR dan dilewati.
+func exportHAR(entries []Request) ([]byte, int, error) {
+	har := harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "panggil", Version: Version},
+		Entries: []harEntry{},
+	}}
+
+	skipped := 0
+	for _, entry := range entries {
+		if entry.Type == "grpc" {
+			skipped++
+			continue
+		}
+		har.Log.Entries = append(har.Log.Entries, harEntryFor(entry))
+	}
+
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return nil, skipped, fmt.Errorf("marshaling HAR: %w", err)
+	}
+	return data, skipped, nil
+}
+
+// harEntryFor converts one history entry into a HAR entry.
+// harEntryFor mengkonversi satu entri History menjadi entri HAR.
+func harEntryFor(entry Request) harEntry {
+	data := HttpRequestData{
+		Method:    entry.Method,
+		URL:       entry.URL,
+		Headers:   entry.Headers,
+		Body:      entry.Body,
+		AuthType:  getAuthTypeName(entry.AuthType),
+		AuthToken: entry.AuthToken,
+		AuthUser:  entry.AuthUser,
+		AuthPass:  entry.AuthPass,
+	}
+
+	// Rebuild the request to include the headers the auth settings added when it was sent.
+	// Membangun ulang request agar header yang ditambahkan oleh pengaturan auth saat dikirim ikut disertakan.
+	headers := http.Header{}
+	for k, v := range entry.Headers {
+		headers.Set(k, v)
+	}
+	if httpReq, err := newHttpRequest(data); err == nil {
+		headers = httpReq.Header
+	}
+
+	req := harRequest{
+		Method:      entry.Method,
+		URL:         entry.URL,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(headers),
+		QueryString: []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    len(entry.Body),
+	}
+	if u, err := url.Parse(entry.URL); err == nil {
+		for _, k := range sortedStringKeys(u.Query()) {
+			for _, v := range u.Query()[k] {
+				req.QueryString = append(req.QueryString, harNameValue{Name: k, Value: v})
+			}
+		}
+	}
+	if entry.Body != "" {
+		req.PostData = &harPostData{MimeType: headers.Get("Content-Type"), Text: entry.Body}
+	}
+
+	started := entry.Time
+	resp := harResponse{
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []harNameValue{},
+		Headers:     []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+	var elapsed float64
+	if r := entry.Response; r != nil {
+		started = r.StartedAt
+		elapsed = float64(r.Duration.Microseconds()) / 1000
+		resp.Status = r.StatusCode
+		resp.StatusText = strings.TrimSpace(strings.TrimPrefix(r.Status, fmt.Sprint(r.StatusCode)))
+		resp.Headers = harHeaders(r.Headers)
+		resp.BodySize = len(r.Body)
+		resp.Content = harContent{Size: len(r.Body), MimeType: r.Headers.Get("Content-Type")}
+		if utf8.Valid(r.Body) {
+			resp.Content.Text = string(r.Body)
+		} else {
+			resp.Content.Text = base64.StdEncoding.EncodeToString(r.Body)
+			resp.Content.Encoding = "base64"
+		}
+	}
+
+	return harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            elapsed,
+		Request:         req,
+		Response:        resp,
+		// Only the total duration is measured, so it is reported as waiting time.
+		// Hanya durasi total yang diukur, sehingga dilaporkan sebagai waktu tunggu.
+		Timings: harTimings{Send: 0, Wait: elapsed, Receive: 0},
+	}
+}
+
+// harHeaders converts headers into HAR name/value pairs sorted by name.
+// harHeaders mengkonversi header menjadi pasangan name/value HAR yang diurutkan berdasarkan nama.
+func harHeaders(headers http.Header) []harNameValue {
+	pairs := []harNameValue{}
+	for _, k := range sortedStringKeys(headers) {
+		for _, v := range headers[k] {
+			pairs = append(pairs, harNameValue{Name: k, Value: v})
+		}
+	}
+	return pairs
+}
+
+// sortedStringKeys returns the keys of a multi-value map in lexical order.
+// sortedStringKeys mengembalikan key dari map multi-nilai dalam urutan leksikal.
+func sortedStringKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestImportHAR(t *testing.T) {
+	data := []byte(`{
+		"log": {
+			"version": "1.2",
+			"entries": [
+				{
+					"request": {
+						"method": "get",
+						"url": "https://api.test/users?page=2",
+						"headers": [
+							{"name": ":authority", "value": "api.test"},
+							{"name": "Host", "value": "api.test"},
+							{"name": "Accept-Encoding", "value": "gzip"},
+							{"name": "Accept", "value": "text/html"},
+							{"name": "Accept", "value": "application/json"}
+						]
+					}
+				},
+				{
+					"request": {
+						"method": "POST",
+						"url": "https://api.test/login",
+						"headers": [{"name": "Content-Length", "value": "9"}],
+						"postData": {"mimeType": "application/x-www-form-urlencoded", "params": [{"name": "u", "value": "a b"}]}
+					}
+				},
+				{
+					"request": {
+						"method": "POST",
+						"url": "https://api.test/upload",
+						"headers": [{"name": "content-type", "value": "multipart/form-data; boundary=x"}],
+						"postData": {"mimeType": "multipart/form-data; boundary=x", "text": "--x--"}
+					}
+				}
+			]
+		}
+	}`)
+
+	result, err := importHAR(data, "capture.har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := result.Collection
+	if root.Name != "capture.har" || len(root.Children) != 3 {
+		t.Fatalf("unexpected folder %q with %d children", root.Name, len(root.Children))
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the multipart body", result.Warnings)
+	}
+
+	tests := []struct {
+		name        string
+		req         *Request
+		wantName    string
+		wantMethod  string
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "pseudo and computed headers are dropped",
+			req:         root.Children[0].Request,
+			wantName:    "GET api.test/users",
+			wantMethod:  "GET",
+			wantHeaders: map[string]string{"Accept": "text/html, application/json"},
+		},
+		{
+			name:        "form params become the body",
+			req:         root.Children[1].Request,
+			wantName:    "POST api.test/login",
+			wantMethod:  "POST",
+			wantBody:    "u=a+b",
+			wantHeaders: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		},
+		{
+			name:        "existing content type is kept",
+			req:         root.Children[2].Request,
+			wantName:    "POST api.test/upload",
+			wantMethod:  "POST",
+			wantBody:    "--x--",
+			wantHeaders: map[string]string{"content-type": "multipart/form-data; boundary=x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.Name != tt.wantName || req.Method != tt.wantMethod || req.Body != tt.wantBody {
+				t.Errorf("got %q %s %q, want %q %s %q", req.Name, req.Method, req.Body, tt.wantName, tt.wantMethod, tt.wantBody)
+			}
+			if !reflect.DeepEqual(req.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", req.Headers, tt.wantHeaders)
+			}
+		})
+	}
+}
+
+func TestImportHARPageTitle(t *testing.T) {
+	data := []byte(`{"log": {"pages": [{"title": "Checkout"}], "entries": []}}`)
+	result, err := importHAR(data, "file.har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Collection.Name != "Checkout" {
+		t.Errorf("folder name = %q, want the page title", result.Collection.Name)
+	}
+}
+
+func TestIsHAR(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{`{"log": {"version": "1.2", "entries": []}}`, true},
+		{`{"log": {"version": "1.2"}}`, false},
+		{`{"info": {}, "item": []}`, false},
+		{`GET https://x`, false},
+	}
+	for _, tt := range tests {
+		if got := isHAR([]byte(tt.data)); got != tt.want {
+			t.Errorf("isHAR(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestExportHARRoundTrip(t *testing.T) {
+	started := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	entries := []Request{
+		{
+			Type:    "http",
+			Method:  "POST",
+			URL:     "https://api.test/items?tag=a",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    `{"a":1}`,
+			Response: &ResponseRecord{
+				StartedAt:  started,
+				Duration:   1500 * time.Microsecond,
+				Status:     "201 Created",
+				StatusCode: 201,
+				Headers:    http.Header{"Content-Type": {"application/json"}},
+				Body:       []byte(`{"id":1}`),
+			},
+		},
+		{Type: "grpc", GrpcServer: "localhost:1", GrpcMethod: "a.B/C"},
+	}
+
+	data, skipped, err := exportHAR(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want the gRPC entry", skipped)
+	}
+
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatal(err)
+	}
+	if len(har.Log.Entries) != 1 {
+		t.Fatalf("got %d entries", len(har.Log.Entries))
+	}
+	entry := har.Log.Entries[0]
+	if entry.Response.Status != 201 || entry.Response.StatusText != "Created" || entry.Response.Content.Text != `{"id":1}` {
+		t.Errorf("response = %+v", entry.Response)
+	}
+	if entry.Time != 1.5 || entry.StartedDateTime != started.Format(time.RFC3339Nano) {
+		t.Errorf("timing = %v at %s", entry.Time, entry.StartedDateTime)
+	}
+	if !reflect.DeepEqual(entry.Request.QueryString, []harNameValue{{Name: "tag", Value: "a"}}) {
+		t.Errorf("query string = %v", entry.Request.QueryString)
+	}
+
+	imported, err := importHAR(data, "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := imported.Collection.Children[0].Request
+	if req.Method != "POST" || req.URL != entries[0].URL || req.Body != entries[0].Body || req.Headers["Content-Type"] != "application/json" {
+		t.Errorf("round trip = %s %s %q %v", req.Method, req.URL, req.Body, req.Headers)
+	}
+}
@@ -38,35 +38,15 @@ type HttpResponseData struct {
 // It has no dependency on the UI (tview). /
 // doHttpRequest adalah fungsi murni yang mengirim sebuah request HTTP dan mengembalikan hasilnya. Fungsi ini tidak memiliki dependensi ke UI (tview).
 func doHttpRequest(data HttpRequestData) *HttpResponseData {
-	var bodyReader io.Reader
-	if data.Body != "" {
-		bodyReader = bytes.NewBufferString(data.Body)
-	}
-
-	req, err := http.NewRequest(data.Method, data.URL, bodyReader)
+	req, err := newHttpRequest(data)
 	if err != nil {
 		log.Printf("ERROR: Failed to create HTTP request for %s %s: %v", data.Method, data.URL, err)
-		return &HttpResponseData{Error: fmt.Errorf("creating request: %w", err)}
-	}
-
-	for k, v := range data.Headers {
-		req.Header.Set(k, v)
-	}
-
-	switch data.AuthType {
-	case "Bearer Token":
-		if data.AuthToken != "" {
-			req.Header.Set("Authorization", "Bearer "+data.AuthToken)
-		}
-	case "Basic Auth":
-		if data.AuthUser != "" {
-			req.SetBasicAuth(data.AuthUser, data.AuthPass)
-		}
+		return &HttpResponseData{Error: err}
 	}
 
 	log.Printf("INFO: Sending HTTP request: %s %s", data.Method, data.URL)
 	start := time.Now()
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: settings.httpTimeout()}
 	resp, err := client.Do(req)
 	duration := time.Since(start)
 
@@ -94,3 +74,38 @@ func doHttpRequest(data HttpRequestData) *HttpResponseData {
 		Error:         nil,
 	}
 }
+
+// newHttpRequest builds the *http.Request for data, including the headers added by the auth settings.
+// newHttpRequest membuat *http.Request untuk data, termasuk header yang ditambahkan oleh pengaturan auth.
+func newHttpRequest(data HttpRequestData) (*http.Request, error) {
+	var bodyReader io.Reader
+	if data.Body != "" {
+		bodyReader = bytes.NewBufferString(data.Body)
+	}
+
+	req, err := http.NewRequest(data.Method, data.URL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// Default headers from the settings give way to the request's own headers.
+	// Header default dari settings mengalah pada header milik request itu sendiri.
+	for k, v := range settings.DefaultHeaders {
+		req.Header.Set(k, v)
+	}
+	for k, v := range data.Headers {
+		req.Header.Set(k, v)
+	}
+
+	switch data.AuthType {
+	case "Bearer Token":
+		if data.AuthToken != "" {
+			req.Header.Set("Authorization", "Bearer "+data.AuthToken)
+		}
+	case "Basic Auth":
+		if data.AuthUser != "" {
+			req.SetBasicAuth(data.AuthUser, data.AuthPass)
+		}
+	}
+	return req, nil
+}
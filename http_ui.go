@@ -114,6 +114,12 @@ func (a *App) createAuthPanel() {
 		SetMaskCharacter('*').
 		SetFieldBackgroundColor(tcell.ColorBlack)
 
+	a.authSecret = tview.NewCheckbox().
+		SetLabel("Secret ").
+		SetChangedFunc(func(checked bool) {
+			a.applySecretMasks()
+		})
+
 	a.authPanel = tview.NewFlex()
 	a.authPanel.SetBorder(true).SetTitle("Authorization")
 	a.authPanel.AddItem(a.authType, 30, 0, false)
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// isHTTPFilePath reports whether path has the extension of a .http/.rest request file.
+// isHTTPFilePath melaporkan apakah path memiliki ekstensi file request .http/.rest.
+func isHTTPFilePath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".http" || ext == ".rest"
+}
+
+var (
+	httpFileRequestLine = regexp.MustCompile(`^(GET|POST|PUT|DELETE|PATCH|HEAD|OPTIONS|TRACE|CONNECT|GRPC|GRAPHQL|WEBSOCKET)\s+(\S+)(\s+HTTP/\S+)?\s*$`)
+	httpFileVariable    = regexp.MustCompile(`^@([A-Za-z0-9_.-]+)\s*=\s*(.*)$`)
+	httpFileNameTag     = regexp.MustCompile(`^(#|//)\s*@name(\s*=\s*|\s+)(\S.*)$`)
+)
+
+// parseHTTPFile converts a .http/.rest file (JetBrains HTTP Client / VS Code REST Client
+// format) into a folder called name. Requests are separated by ### lines and file-level
+// @variables become an environment with the same name. /
+// parseHTTPFile mengkonversi file .http/.rest (format JetBrains HTTP Client / VS Code REST
+// Client) menjadi folder bernama name. Request dipisahkan oleh baris ### dan @variabel di
+// level file menjadi environment dengan nama yang sama.
+func parseHTTPFile(data []byte, name string) (*ImportResult, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	root := &CollectionNode{Name: name, IsFolder: true}
+	vars := make(map[string]string)
+	var warnings []string
+
+	var block []string
+	title := ""
+	flush := func() {
+		req, warning := parseHTTPFileBlock(block, title, vars)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		if req != nil {
+			root.Children = append(root.Children, &CollectionNode{Name: req.Name, Request: req})
+		}
+		block = nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "###") {
+			flush()
+			title = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+
+	if len(root.Children) == 0 && len(vars) == 0 {
+		return nil, fmt.Errorf("no requests found in %s", name)
+	}
+	result := &ImportResult{Collection: root, Warnings: warnings}
+	if len(vars) > 0 {
+		result.Environments = append(result.Environments, &Environment{Name: name, Variables: vars})
+	}
+	return result, nil
+}
+
+// parseHTTPFileBlock parses the lines between two ### separators. It returns nil when the
+// block only holds comments or variables, and a warning for parts that were skipped. /
+// parseHTTPFileBlock mem-parse baris-baris di antara dua pemisah ###. Mengembalikan nil jika
+// blok hanya berisi komentar atau variabel, serta peringatan untuk bagian yang dilewati.
+func parseHTTPFileBlock(lines []string, title string, vars map[string]string) (*Request, string) {
+	i := 0
+	name := ""
+	method, rawURL := "", ""
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		if m := httpFileNameTag.FindStringSubmatch(line); m != nil {
+			name = strings.TrimSpace(m[3])
+			continue
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if m := httpFileVariable.FindStringSubmatch(line); m != nil {
+			vars[m[1]] = strings.TrimSpace(m[2])
+			continue
+		}
+		if m := httpFileRequestLine.FindStringSubmatch(line); m != nil {
+			method, rawURL = m[1], m[2]
+		} else if !strings.Contains(line, " ") {
+			method, rawURL = "GET", line // A bare URL is a GET request / URL saja berarti request GET
+		} else {
+			return nil, fmt.Sprintf("unrecognized request line %q skipped", line)
+		}
+		i++
+		break
+	}
+	if method == "" {
+		return nil, ""
+	}
+	if name == "" {
+		name = title
+	}
+	if name == "" {
+		name = method + " " + rawURL
+	}
+	if method == "GRAPHQL" || method == "WEBSOCKET" {
+		return nil, fmt.Sprintf("%s request '%s' skipped", method, name)
+	}
+
+	// Query continuation lines start with ? or & / Baris lanjutan query diawali ? atau &
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "?") && !strings.HasPrefix(line, "&") {
+			break
+		}
+		rawURL += line
+	}
+
+	headers := make(map[string]string)
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			i++
+			break
+		}
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			break // Body without a blank line / Body tanpa baris kosong
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	var warning string
+	var bodyLines []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "> ") || strings.HasPrefix(trimmed, "<> ") {
+			warning = fmt.Sprintf("response handler of '%s' skipped", name)
+			break
+		}
+		if strings.HasPrefix(trimmed, "< ") && len(bodyLines) == 0 {
+			warning = fmt.Sprintf("body file %s of '%s' skipped", strings.TrimSpace(trimmed[2:]), name)
+			break
+		}
+		bodyLines = append(bodyLines, line)
+	}
+	body := strings.TrimSpace(strings.Join(bodyLines, "\n"))
+
+	req := &Request{Name: name, Body: body, Time: time.Now()}
+	if method == "GRPC" {
+		target := strings.TrimPrefix(strings.TrimPrefix(rawURL, grpcURLScheme), "grpcs://")
+		server, grpcMethod, _ := strings.Cut(target, "/")
+		req.Type = "grpc"
+		req.GrpcServer = server
+		req.GrpcMethod = grpcMethod
+		if len(headers) > 0 {
+			raw, _ := json.MarshalIndent(headers, "", "  ")
+			req.GrpcMetadata = string(raw)
+		}
+		return req, warning
+	}
+
+	req.Type = "http"
+	req.Method = method
+	req.URL = rawURL
+	req.Headers = headers
+	extractAuthorizationHeader(req)
+	if len(req.Headers) > 0 {
+		raw, _ := json.MarshalIndent(req.Headers, "", "  ")
+		req.HeadersRaw = string(raw)
+	}
+	return req, warning
+}
+
+// exportHTTPFile writes the requests under node as a .http file, preceded by vars as file
+// variables. Nested folders are flattened; their path becomes part of the request name. /
+// exportHTTPFile menulis request di bawah node sebagai file .http, diawali vars sebagai
+// variabel file. Folder bertingkat diratakan; path-nya menjadi bagian dari nama request.
+func exportHTTPFile(node *CollectionNode, vars map[string]string) ([]byte, error) {
+	var b bytes.Buffer
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "@%s = %s\n", k, vars[k])
+	}
+
+	for _, item := range httpFileItems(node, "") {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		req := item.request
+		fmt.Fprintf(&b, "### %s\n", item.path)
+
+		if req.Type == "grpc" {
+			fmt.Fprintf(&b, "GRPC %s/%s\n", req.GrpcServer, req.GrpcMethod)
+			meta, err := parseGrpcMetadata(req.GrpcMetadata)
+			if err != nil {
+				return nil, fmt.Errorf("request '%s': %w", item.path, err)
+			}
+			writeHTTPFileHeaders(&b, meta)
+		} else {
+			method := req.Method
+			if method == "" {
+				method = "GET"
+			}
+			fmt.Fprintf(&b, "%s %s\n", method, req.URL)
+			headers := req.Headers
+			if strings.TrimSpace(req.HeadersRaw) != "" {
+				headers = make(map[string]string)
+				if err := json.Unmarshal([]byte(req.HeadersRaw), &headers); err != nil {
+					return nil, fmt.Errorf("request '%s': parsing headers: %w", item.path, err)
+				}
+			}
+			writeHTTPFileHeaders(&b, headers)
+			switch getAuthTypeName(req.AuthType) {
+			case "Bearer Token":
+				fmt.Fprintf(&b, "Authorization: Bearer %s\n", req.AuthToken)
+			case "Basic Auth":
+				fmt.Fprintf(&b, "Authorization: Basic %s\n", httpFileBasicCredentials(req.AuthUser, req.AuthPass))
+			}
+		}
+
+		if req.Body != "" {
+			b.WriteString("\n" + strings.TrimRight(req.Body, "\n") + "\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// httpFileItems flattens node into its requests, naming each one after its folder path
+// joined with " / " (request names often contain slashes themselves). /
+// httpFileItems meratakan node menjadi request-request, masing-masing diberi nama sesuai path
+// folder-nya yang digabung dengan " / " (nama request sendiri sering berisi garis miring).
+func httpFileItems(node *CollectionNode, prefix string) []runItem {
+	var items []runItem
+	for _, child := range node.Children {
+		path := child.Name
+		if prefix != "" {
+			path = prefix + " / " + child.Name
+		}
+		if child.IsFolder {
+			items = append(items, httpFileItems(child, path)...)
+		} else if child.Request != nil {
+			items = append(items, runItem{path: path, request: child.Request})
+		}
+	}
+	return items
+}
+
+// writeHTTPFileHeaders writes headers as "Name: value" lines sorted by name.
+// writeHTTPFileHeaders menulis header sebagai baris "Name: value" yang diurutkan berdasarkan nama.
+func writeHTTPFileHeaders(b *bytes.Buffer, headers map[string]string) {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s: %s\n", k, headers[k])
+	}
+}
+
+// httpFileBasicCredentials encodes Basic credentials. Credentials containing {{variables}}
+// are written as user:pass, which both REST clients expand before encoding. /
+// httpFileBasicCredentials meng-encode kredensial Basic. Kredensial yang berisi {{variabel}}
+// ditulis sebagai user:pass, yang di-expand oleh kedua REST client sebelum di-encode.
+func httpFileBasicCredentials(user, pass string) string {
+	if strings.Contains(user+pass, "{{") {
+		return user + ":" + pass
+	}
+	return base64.StdEncoding.EncodeToString([]byte(user + ":" + pass))
+}
+
+// openHTTPFile adds a .http file to the collections tree as a live folder. The folder is not
+// stored in collections.json; changes to it are written back to the file instead. /
+// openHTTPFile menambahkan file .http ke tree Collections sebagai folder live. Folder tersebut
+// tidak disimpan di collections.json; perubahannya ditulis kembali ke file tersebut.
+func (a *App) openHTTPFile(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(filepath.Base(absPath), filepath.Ext(absPath))
+	data, err := os.ReadFile(absPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	result := &ImportResult{Collection: &CollectionNode{Name: name, IsFolder: true}}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if result, err = parseHTTPFile(data, name); err != nil {
+			return err
+		}
+	}
+	for _, w := range result.Warnings {
+		log.Printf("WARN: %s: %s", path, w)
+	}
+
+	node := result.Collection
+	node.SourceFile = absPath
+	node.Expanded = true
+	a.collectionsRoot.Children = append(a.collectionsRoot.Children, node)
+	if len(result.Environments) > 0 {
+		a.applyImport(&ImportResult{Environments: result.Environments})
+	}
+
+	// Remember the normalized content so the file is only rewritten after a real change.
+	// Simpan konten yang sudah dinormalisasi agar file hanya ditulis ulang setelah ada perubahan.
+	if a.liveFileContents == nil {
+		a.liveFileContents = make(map[string]string)
+	}
+	current, err := exportHTTPFile(node, a.liveFileVariables(node))
+	if err != nil {
+		return err
+	}
+	a.liveFileContents[absPath] = string(current)
+	log.Printf("INFO: Opened %s as a live collection with %d request(s)", absPath, len(node.Children))
+	return nil
+}
+
+// liveFileVariables returns the variables written at the top of a live .http file: those of
+// the environment named after the file. /
+// liveFileVariables mengembalikan variabel yang ditulis di bagian atas file .http live: variabel
+// dari environment yang bernama sama dengan file tersebut.
+func (a *App) liveFileVariables(node *CollectionNode) map[string]string {
+	if env := a.environmentByName(node.Name); env != nil {
+		return env.Variables
+	}
+	return nil
+}
+
+// saveLiveFiles writes live .http folders back to their files when their content changed.
+// saveLiveFiles menulis folder .http live kembali ke file-nya jika kontennya berubah.
+func (a *App) saveLiveFiles() {
+	for _, node := range a.collectionsRoot.Children {
+		if node.SourceFile == "" {
+			continue
+		}
+		data, err := exportHTTPFile(node, a.liveFileVariables(node))
+		if err != nil {
+			log.Printf("ERROR: Failed to convert '%s' to a .http file: %v", node.Name, err)
+			continue
+		}
+		if a.liveFileContents[node.SourceFile] == string(data) {
+			continue
+		}
+		if err := writeFileAtomic(node.SourceFile, data, 0644); err != nil {
+			log.Printf("ERROR: Failed to write %s: %v", node.SourceFile, err)
+			continue
+		}
+		a.liveFileContents[node.SourceFile] = string(data)
+		log.Printf("INFO: Saved live collection to %s", node.SourceFile)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testHTTPFile = `@baseUrl = https://api.test
+@token = abc
+
+### List users
+GET {{baseUrl}}/users
+  ?page=2
+  &size=10
+Accept: application/json
+
+### Create
+# @name createUser
+POST {{baseUrl}}/users HTTP/1.1
+Content-Type: application/json
+Authorization: Bearer {{token}}
+
+{
+  "name": "a"
+}
+
+> {% client.global.set("id", response.body.id) %}
+
+###
+https://api.test/health
+
+### Ping
+GRPC grpc://localhost:50051/pkg.Health/Check
+authorization: Bearer t
+
+{"service": ""}
+
+### Live
+WEBSOCKET ws://localhost/socket
+`
+
+func TestParseHTTPFile(t *testing.T) {
+	result, err := parseHTTPFile([]byte(strings.ReplaceAll(testHTTPFile, "\n", "\r\n")), "api.http")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantVars := map[string]string{"baseUrl": "https://api.test", "token": "abc"}
+	if len(result.Environments) != 1 || result.Environments[0].Name != "api.http" || !reflect.DeepEqual(result.Environments[0].Variables, wantVars) {
+		t.Errorf("environments = %+v, want api.http with %v", result.Environments, wantVars)
+	}
+	if len(result.Warnings) != 2 {
+		t.Errorf("warnings = %q, want one for the response handler and one for the WebSocket request", result.Warnings)
+	}
+
+	children := result.Collection.Children
+	if len(children) != 4 {
+		t.Fatalf("got %d requests, want 4", len(children))
+	}
+
+	tests := []struct {
+		name        string
+		req         *Request
+		wantName    string
+		wantMethod  string
+		wantURL     string
+		wantBody    string
+		wantHeaders map[string]string
+		wantAuth    int
+		wantToken   string
+	}{
+		{
+			name:        "title and query continuation lines",
+			req:         children[0].Request,
+			wantName:    "List users",
+			wantMethod:  "GET",
+			wantURL:     "{{baseUrl}}/users?page=2&size=10",
+			wantHeaders: map[string]string{"Accept": "application/json"},
+		},
+		{
+			name:        "name tag, body and bearer header",
+			req:         children[1].Request,
+			wantName:    "createUser",
+			wantMethod:  "POST",
+			wantURL:     "{{baseUrl}}/users",
+			wantBody:    "{\n  \"name\": \"a\"\n}",
+			wantHeaders: map[string]string{"Content-Type": "application/json"},
+			wantAuth:    getAuthTypeIndex("Bearer Token"),
+			wantToken:   "{{token}}",
+		},
+		{
+			name:        "bare URL",
+			req:         children[2].Request,
+			wantName:    "GET https://api.test/health",
+			wantMethod:  "GET",
+			wantURL:     "https://api.test/health",
+			wantHeaders: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.Type != "http" || req.Name != tt.wantName || req.Method != tt.wantMethod || req.URL != tt.wantURL || req.Body != tt.wantBody {
+				t.Errorf("got %q %s %s %q, want %q %s %s %q", req.Name, req.Method, req.URL, req.Body, tt.wantName, tt.wantMethod, tt.wantURL, tt.wantBody)
+			}
+			if !reflect.DeepEqual(req.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", req.Headers, tt.wantHeaders)
+			}
+			if req.AuthType != tt.wantAuth || req.AuthToken != tt.wantToken {
+				t.Errorf("auth = %d %q, want %d %q", req.AuthType, req.AuthToken, tt.wantAuth, tt.wantToken)
+			}
+		})
+	}
+
+	ping := children[3].Request
+	if ping.Type != "grpc" || ping.GrpcServer != "localhost:50051" || ping.GrpcMethod != "pkg.Health/Check" || ping.Body != `{"service": ""}` {
+		t.Errorf("gRPC request = %s %s %s %q", ping.Type, ping.GrpcServer, ping.GrpcMethod, ping.Body)
+	}
+	if !strings.Contains(ping.GrpcMetadata, `"authorization": "Bearer t"`) {
+		t.Errorf("gRPC metadata = %q", ping.GrpcMetadata)
+	}
+}
+
+func TestParseHTTPFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "empty", data: "", wantErr: true},
+		{name: "comments only", data: "# nothing here\n// or here\n", wantErr: true},
+		{name: "variables only", data: "@host = localhost\n"},
+		{name: "unrecognized line", data: "FETCH the thing\n###\nGET https://x\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseHTTPFile([]byte(tt.data), "x.http")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPFileRoundTrip(t *testing.T) {
+	node := &CollectionNode{Name: "API", IsFolder: true, Children: []*CollectionNode{
+		{Name: "Users", IsFolder: true, Children: []*CollectionNode{
+			{Name: "Create", Request: &Request{
+				Type:     "http",
+				Method:   "POST",
+				URL:      "{{baseUrl}}/users",
+				Headers:  map[string]string{"Content-Type": "application/json"},
+				Body:     `{"a":1}`,
+				AuthType: getAuthTypeIndex("Basic Auth"),
+				AuthUser: "u",
+				AuthPass: "p",
+			}},
+		}},
+		{Name: "Ping", Request: &Request{Type: "grpc", GrpcServer: "localhost:1", GrpcMethod: "a.B/C", Body: "{}"}},
+	}}
+
+	data, err := exportHTTPFile(node, map[string]string{"baseUrl": "https://api.test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := parseHTTPFile(data, "API")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Environments[0].Variables["baseUrl"]; got != "https://api.test" {
+		t.Errorf("baseUrl = %q", got)
+	}
+	children := result.Collection.Children
+	if len(children) != 2 {
+		t.Fatalf("got %d requests, want 2", len(children))
+	}
+	create := children[0].Request
+	if create.Name != "Users / Create" || create.Method != "POST" || create.Body != `{"a":1}` {
+		t.Errorf("create = %q %s %q", create.Name, create.Method, create.Body)
+	}
+	if create.AuthType != getAuthTypeIndex("Basic Auth") || create.AuthUser != "u" || create.AuthPass != "p" {
+		t.Errorf("auth = %d %q %q", create.AuthType, create.AuthUser, create.AuthPass)
+	}
+	if ping := children[1].Request; ping.Type != "grpc" || ping.GrpcMethod != "a.B/C" {
+		t.Errorf("ping = %s %s", ping.Type, ping.GrpcMethod)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImportResult is what an importer produces: an optional collection folder, any
+// environments, and warnings about features that could not be converted. /
+// ImportResult adalah hasil dari sebuah importer: folder collection opsional, environment,
+// dan peringatan tentang fitur yang tidak dapat dikonversi.
+type ImportResult struct {
+	Format       string
+	Collection   *CollectionNode
+	Environments []*Environment
+	Warnings     []string
+}
+
+// importFile reads a file and converts it with the importer matching its content.
+// importFile membaca sebuah file dan mengkonversinya dengan importer yang sesuai dengan isinya.
+func importFile(path string) (*ImportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading import file: %w", err)
+	}
+
+	var result *ImportResult
+	switch {
+	case isHTTPFilePath(path):
+		result, err = parseHTTPFile(data, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		if result != nil {
+			result.Format = "HTTP request file"
+		}
+	case isPostmanCollection(data):
+		result, err = importPostmanCollection(data)
+		if result != nil {
+			result.Format = "Postman collection"
+		}
+	case isPostmanEnvironment(data):
+		result, err = importPostmanEnvironment(data)
+		if result != nil {
+			result.Format = "Postman environment"
+		}
+	case isHAR(data):
+		result, err = importHAR(data, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		if result != nil {
+			result.Format = "HAR file"
+		}
+	case isOpenAPIDocument(data):
+		result, err = importOpenAPI(data)
+		if result != nil {
+			result.Format = "OpenAPI document"
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized import format for %s", filepath.Base(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyImport adds an import result to the collections tree and environments, then saves both.
+// Environments with the same name as an existing one are merged into it. /
+// applyImport menambahkan hasil import ke tree Collections dan environments, lalu menyimpan keduanya.
+// Environment dengan nama yang sama dengan yang sudah ada akan digabungkan ke dalamnya.
+func (a *App) applyImport(result *ImportResult) {
+	if result.Collection != nil {
+		a.collectionsRoot.Children = append(a.collectionsRoot.Children, result.Collection)
+		a.saveCollections()
+	}
+
+	if len(result.Environments) > 0 {
+		for _, imported := range result.Environments {
+			if existing := a.environmentByName(imported.Name); existing != nil {
+				if existing.Variables == nil {
+					existing.Variables = make(map[string]string)
+				}
+				for k, v := range imported.Variables {
+					existing.Variables[k] = v
+				}
+				continue
+			}
+			a.environments = append(a.environments, imported)
+		}
+		a.saveEnvironments()
+		a.refreshEnvDropdown()
+	}
+}
+
+// environmentByName returns the environment with the given name, ignoring case, or nil.
+// environmentByName mengembalikan environment dengan nama tertentu, tanpa memperhatikan huruf besar/kecil, atau nil.
+func (a *App) environmentByName(name string) *Environment {
+	for _, env := range a.environments {
+		if strings.EqualFold(env.Name, name) {
+			return env
+		}
+	}
+	return nil
+}
+
+// summary describes the import result in one line.
+// summary menjelaskan hasil import dalam satu baris.
+func (r *ImportResult) summary() string {
+	var parts []string
+	if r.Collection != nil {
+		folders, requests := countCollectionNodes(r.Collection)
+		parts = append(parts, fmt.Sprintf("'%s' with %d folder(s) and %d request(s)", r.Collection.Name, folders, requests))
+	}
+	for _, env := range r.Environments {
+		parts = append(parts, fmt.Sprintf("environment '%s' with %d variable(s)", env.Name, len(env.Variables)))
+	}
+	return fmt.Sprintf("Imported %s: %s", r.Format, strings.Join(parts, ", "))
+}
+
+// countCollectionNodes counts the folders and requests below node.
+// countCollectionNodes menghitung folder dan request di bawah node.
+func countCollectionNodes(node *CollectionNode) (folders, requests int) {
+	for _, child := range node.Children {
+		if child.IsFolder {
+			folders++
+			f, r := countCollectionNodes(child)
+			folders += f
+			requests += r
+		} else {
+			requests++
+		}
+	}
+	return folders, requests
+}
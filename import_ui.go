@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// showImportModal asks for a file to import into the collections and environments.
+// showImportModal meminta file yang akan di-import ke Collections dan environments.
+func (a *App) showImportModal() {
+	pathInput := tview.NewInputField().SetLabel("File").SetFieldWidth(50).
+		SetPlaceholder("e.g. ~/Downloads/api.postman_collection.json or openapi.yaml")
+
+	closeModal := func() {
+		a.rootPages.RemovePage("importModal")
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(pathInput).
+		AddButton("Import", func() {
+			path := expandHomePath(strings.TrimSpace(pathInput.GetText()))
+			if path == "" {
+				return
+			}
+			result, err := importFile(path)
+			if err != nil {
+				log.Printf("ERROR: Import of %s failed: %v", path, err)
+				form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+				return
+			}
+			a.applyImport(result)
+			a.populateCollectionsTree()
+			a.flattenCollections()
+			log.Printf("INFO: %s from %s", result.summary(), path)
+			closeModal()
+			a.showImportResultModal(result)
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+
+	form.SetBorder(true).SetTitle(" Import (Postman, OpenAPI/Swagger, HAR, .http) ")
+	modal := a.createModal(form, 80, 7)
+	a.rootPages.AddPage("importModal", modal, true, true)
+	a.app.SetFocus(pathInput)
+}
+
+// showImportResultModal lists what was imported and which features were skipped.
+// showImportResultModal menampilkan apa yang di-import dan fitur mana yang dilewati.
+func (a *App) showImportResultModal(result *ImportResult) {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("[green]%s[-]\n", tview.Escape(result.summary())))
+	if len(result.Warnings) == 0 {
+		b.WriteString("\nNo unsupported features were found.")
+	} else {
+		b.WriteString(fmt.Sprintf("\n[yellow]Skipped (%d):[-]\n", len(result.Warnings)))
+		for _, w := range result.Warnings {
+			b.WriteString("  - " + tview.Escape(w) + "\n")
+		}
+	}
+
+	textView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(b.String())
+	textView.SetBorder(true).SetTitle(" Import Result (Esc to close) ")
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || event.Key() == tcell.KeyEnter {
+			a.rootPages.RemovePage("importResultModal")
+			a.app.SetFocus(a.collectionsTree)
+			return nil
+		}
+		return event
+	})
+
+	modal := a.createModal(textView, 90, 20)
+	a.rootPages.AddPage("importResultModal", modal, true, true)
+	a.app.SetFocus(textView)
+}
+
+// showExportModal asks for the format and destination file for exporting node.
+// showExportModal meminta format dan file tujuan untuk meng-export node.
+func (a *App) showExportModal(node *CollectionNode) {
+	if node == nil {
+		node = a.collectionsRoot
+	}
+
+	pathInput := tview.NewInputField().SetLabel("File").SetFieldWidth(50).
+		SetText(defaultExportFileName(node, exportFormats[0]))
+	formatDrop := tview.NewDropDown().SetLabel("Format").SetOptions(exportFormats, func(text string, index int) {
+		pathInput.SetText(defaultExportFileName(node, text))
+	})
+	formatDrop.SetCurrentOption(0)
+
+	closeModal := func() {
+		a.rootPages.RemovePage("exportModal")
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(formatDrop).
+		AddFormItem(pathInput).
+		AddButton("Export", func() {
+			path := expandHomePath(strings.TrimSpace(pathInput.GetText()))
+			if path == "" {
+				return
+			}
+			_, format := formatDrop.GetCurrentOption()
+			if err := exportCollectionToFile(node, format, path); err != nil {
+				log.Printf("ERROR: Export of '%s' failed: %v", node.Name, err)
+				form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+				return
+			}
+			log.Printf("INFO: Exported '%s' as %s to %s", node.Name, format, path)
+			closeModal()
+			a.app.SetFocus(a.collectionsTree)
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Export: %s ", node.Name))
+	modal := a.createModal(form, 80, 9)
+	a.rootPages.AddPage("exportModal", modal, true, true)
+	a.app.SetFocus(pathInput)
+}
+
+// showHistoryExportModal exports the marked history entries, or the selected one when none
+// are marked, as a HAR file. /
+// showHistoryExportModal meng-export entri History yang ditandai, atau entri yang dipilih jika
+// tidak ada yang ditandai, sebagai file HAR.
+func (a *App) showHistoryExportModal() {
+	var entries []Request
+	for i, req := range a.history {
+		if a.historyMarks[i] {
+			entries = append(entries, req)
+		}
+	}
+	if len(entries) == 0 {
+		index := a.historyList.GetCurrentItem()
+		if index < 0 || index >= len(a.history) {
+			return
+		}
+		entries = append(entries, a.history[index])
+	}
+
+	pathInput := tview.NewInputField().SetLabel("File").SetFieldWidth(50).
+		SetText("panggil-" + time.Now().Format("20060102-150405") + ".har")
+
+	closeModal := func() {
+		a.rootPages.RemovePage("historyExportModal")
+		a.app.SetFocus(a.historyList)
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(pathInput).
+		AddButton("Export", func() {
+			path := expandHomePath(strings.TrimSpace(pathInput.GetText()))
+			if path == "" {
+				return
+			}
+			data, skipped, err := exportHAR(a.redactHistory(entries))
+			if err == nil {
+				err = os.WriteFile(path, data, 0644)
+			}
+			if err != nil {
+				log.Printf("ERROR: HAR export failed: %v", err)
+				form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+				return
+			}
+			log.Printf("INFO: Exported %d history entries as HAR to %s (%d gRPC entries skipped)", len(entries)-skipped, path, skipped)
+			closeModal()
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Export %d History Entries as HAR ", len(entries)))
+	modal := a.createModal(form, 80, 7)
+	a.rootPages.AddPage("historyExportModal", modal, true, true)
+	a.app.SetFocus(pathInput)
+}
+
+// showPasteCommandModal turns a pasted curl or grpcurl command into the current request.
+// gRPC requests connect to the server right away so the method can be sent. /
+// showPasteCommandModal mengubah command curl atau grpcurl yang di-paste menjadi request saat ini.
+// Request gRPC langsung terhubung ke server sehingga method dapat dikirim.
+func (a *App) showPasteCommandModal() {
+	commandText := tview.NewTextArea().
+		SetPlaceholder("curl -X POST 'https://api.example.com/users' -H 'Content-Type: application/json' -d '{\"name\":\"x\"}'\n" +
+			"grpcurl -plaintext -H 'authorization: Bearer t' -d '{\"id\":1}' localhost:8081 users.UserService/GetUser")
+	commandText.SetBorder(true).SetTitle(" Command ")
+
+	closeModal := func() {
+		a.rootPages.RemovePage("pasteCommandModal")
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddButton("Import", func() {
+			req, warnings, err := parsePastedCommand(commandText.GetText())
+			if err != nil {
+				log.Printf("ERROR: Pasted command could not be imported: %v", err)
+				form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+				return
+			}
+			for _, w := range warnings {
+				log.Printf("WARN: Pasted command: %s", w)
+			}
+			closeModal()
+			if req.Type == "grpc" {
+				log.Printf("INFO: Imported pasted grpcurl command: %s %s", req.GrpcServer, req.GrpcMethod)
+				a.loadGrpcRequest(*req)
+				return
+			}
+			log.Printf("INFO: Imported pasted curl command: %s %s", req.Method, req.URL)
+			a.loadRequest(*req)
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(commandText, 0, 1, true).
+		AddItem(form, 3, 0, false)
+	layout.SetBorder(true).SetTitle(" Paste curl / grpcurl Command (Tab: buttons, Esc: cancel) ")
+
+	commandText.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyTab:
+			a.app.SetFocus(form)
+			return nil
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		}
+		return event
+	})
+
+	modal := a.createModal(layout, 100, 20)
+	a.rootPages.AddPage("pasteCommandModal", modal, true, true)
+	a.app.SetFocus(commandText)
+}
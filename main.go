@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"strings"
@@ -17,12 +19,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
-	"github.com/jhump/protoreflect/dynamic"
 	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
 	"github.com/jhump/protoreflect/grpcreflect"
 	"google.golang.org/grpc/credentials/insecure"
-
-	"google.golang.org/grpc/metadata"
 )
 
 // Version information injected at build time via ldflags.
@@ -57,6 +56,7 @@ type App struct {
 	authToken      *tview.InputField
 	authUser       *tview.InputField
 	authPass       *tview.InputField
+	authSecret     *tview.Checkbox
 	authPanel      *tview.Flex
 	headersText    *tview.TextArea
 	bodyText       *tview.TextArea
@@ -96,34 +96,89 @@ type App struct {
 	environments   []*Environment
 	activeEnvIndex int
 	envDropdown    *tview.DropDown
+
+	// Scripts attached to the request currently being edited, and their console output.
+	// Script yang terpasang pada request yang sedang diedit, beserta output console-nya.
+	httpScripts  requestScripts
+	grpcScripts  requestScripts
+	consoleLines []string
+
+	// History entries marked for HAR export, by index
+	// Entri History yang ditandai untuk export HAR, berdasarkan index
+	historyMarks map[int]bool
+
+	revealSecrets bool // Show secret values instead of masks / Tampilkan nilai rahasia alih-alih mask
+
+	// Collection nodes the requests being edited were opened from, for folder and request variables
+	// Node collection asal request yang sedang diedit, untuk variabel folder dan request
+	httpSource *CollectionNode
+	grpcSource *CollectionNode
+
+	// Last content written to each live .http file, keyed by path
+	// Konten terakhir yang ditulis ke setiap file .http live, dengan key path
+	liveFileContents map[string]string
+
+	workspaceBtn *tview.Button // Shows the active workspace / Menampilkan workspace yang aktif
 }
 
-// loadCollections reads the collections data from a JSON file in the config directory.
-// loadCollections membaca data collections dari file JSON di direktori config.
+// loadCollections reads the collections data from a JSON file in the config directory, or
+// from the collections directory when one exists. /
+// loadCollections membaca data collections dari file JSON di direktori config, atau dari
+// direktori collections jika ada.
 func (a *App) loadCollections() {
-	path, _ := getConfigPath("collections.json")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		log.Printf("INFO: Collections file not found, will be created on exit.")
+	if dir, err := getWorkspacePath(collectionsDirName); err == nil && isDirectory(dir) {
+		markSynced(dir, fileFingerprint(dir))
+		root, err := loadCollectionDir(dir)
+		if err != nil {
+			markLoadFailed(dir, err)
+			return
+		}
+		markLoaded(dir)
+		root.Expanded = a.collectionsRoot.Expanded
+		a.collectionsRoot = root
+		openCollectionSecrets(a.collectionsRoot)
+		return
+	}
+
+	path, _ := getWorkspacePath("collections.json")
+	markSynced(path, fileFingerprint(path))
+	data, err := readSchemaFile(path, "collections")
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("INFO: Collections file not found, will be created on the first change.")
 		return
 	}
-	if err := json.Unmarshal(data, &a.collectionsRoot); err != nil {
-		log.Printf("ERROR: Failed to unmarshal collections: %v", err)
+	// A file that fails to parse leaves the tree empty and is kept as it is on disk.
+	// File yang gagal di-parse membuat tree kosong dan dibiarkan apa adanya di disk.
+	root := &CollectionNode{}
+	if err == nil {
+		err = json.Unmarshal(data, root)
+	}
+	if err != nil {
+		markLoadFailed(path, err)
+		return
 	}
+	markLoaded(path)
+	a.collectionsRoot = root
+	openCollectionSecrets(a.collectionsRoot)
 }
 
 // loadGrpcCache reads the gRPC request body cache from a JSON file.
 // loadGrpcCache membaca cache body request gRPC dari file JSON.
 func (a *App) loadGrpcCache() {
-	path, _ := getConfigPath("grpc_cache.json")
-	data, err := os.ReadFile(path)
-	if err != nil {
-		log.Printf("INFO: gRPC cache file not found, will be created on exit.")
+	path, _ := getWorkspacePath("grpc_cache.json")
+	data, err := readSchemaFile(path, "grpc_cache")
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("INFO: gRPC cache file not found, will be created on the first change.")
 		return
 	}
-	if err := json.Unmarshal(data, &a.grpcBodyCache); err != nil {
-		log.Printf("ERROR: Failed to unmarshal gRPC cache: %v", err)
+	if err == nil {
+		err = json.Unmarshal(data, &a.grpcBodyCache)
 	}
+	if err != nil {
+		markLoadFailed(path, err)
+		return
+	}
+	markLoaded(path)
 }
 
 // NewApp creates and initializes a new App instance.
@@ -138,7 +193,7 @@ func NewApp() *App {
 			Expanded: true,
 		},
 		grpcBodyCache:        make(map[string]string),
-		explorerPanelVisible: false, // Explorer panel is hidden by default. / Explorer panel disembunyikan secara default.
+		explorerPanelVisible: settings.ShowExplorer, // Hidden unless the settings show it. / Disembunyikan kecuali settings menampilkannya.
 		activeEnvIndex:       0,
 	}
 	app.loadCollections()
@@ -177,18 +232,10 @@ func (a *App) Init() {
 		if collectionNode.IsFolder {
 			node.SetExpanded(!node.IsExpanded())
 			collectionNode.Expanded = node.IsExpanded()
-		} else {
-			req := collectionNode.Request
-			if req != nil {
-				if req.Type == "grpc" {
-					log.Println("Loading gRPC request from collection:", req.Name)
-					a.loadGrpcRequest(*req)
-				} else {
-					log.Println("Loading HTTP request from collection:", req.Name)
-
-					a.loadRequest(*req)
-				}
-			}
+			a.saveCollections()
+		} else if collectionNode.Request != nil {
+			log.Println("Loading request from collection:", collectionNode.Request.Name)
+			a.openCollectionRequest(collectionNode)
 		}
 	})
 	a.collectionsTree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -200,11 +247,31 @@ func (a *App) Init() {
 			a.showDeleteConfirmationModal()
 			return nil
 		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'r' {
+			a.showRunnerModal(a.selectedCollectionNode())
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 't' {
+			a.showRequestTestsModal(a.selectedCollectionNode())
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'i' {
+			a.showImportModal()
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'x' {
+			a.showExportModal(a.selectedCollectionNode())
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'v' {
+			a.showScopeVariablesModal(a.selectedCollectionNode())
+			return nil
+		}
 		return event
 	})
 
 	// Initialize the gRPC server input here so it can be accessed by the page and header. / Inisialisasi input server gRPC di sini agar dapat diakses oleh page dan header.
-	a.grpcServerInput = tview.NewInputField().SetLabel("Server: ").SetText("localhost:8081").SetFieldBackgroundColor(tcell.ColorBlack)
+	a.grpcServerInput = tview.NewInputField().SetLabel("Server: ").SetText(settings.DefaultGrpcServer).SetFieldBackgroundColor(tcell.ColorBlack)
 
 	a.createGrpcPage()
 
@@ -214,6 +281,17 @@ func (a *App) Init() {
 	a.historyList = tview.NewList().ShowSecondaryText(false)
 	a.historyList.SetBorder(true).SetTitle("History")
 	a.historyList.SetSelectedFunc(func(index int, mainText string, secondaryText string, shortcut rune) {})
+	a.historyList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == ' ' {
+			a.toggleHistoryMark(a.historyList.GetCurrentItem())
+			return nil
+		}
+		if event.Key() == tcell.KeyRune && event.Rune() == 'x' {
+			a.showHistoryExportModal()
+			return nil
+		}
+		return event
+	})
 
 	// The explorerPanel holds the collections and history views. / explorerPanel menampung view Collections dan History.
 	a.explorerPanel = tview.NewFlex().SetDirection(tview.FlexRow).AddItem(a.collectionsTree, 0, 1, false).AddItem(a.historyList, 0, 1, false)
@@ -221,7 +299,7 @@ func (a *App) Init() {
 	initialExplorerSize := 0
 	initialExplorerProportion := 0
 	if a.explorerPanelVisible {
-		initialExplorerSize = 40
+		initialExplorerSize = settings.ExplorerWidth
 		initialExplorerProportion = 0 // Gunakan fixed size, bukan proporsi
 	}
 	// contentLayout menampung explorer dan halaman utama (HTTP/gRPC)
@@ -240,17 +318,24 @@ func (a *App) Init() {
 
 [cyan]Global:[-]
   [green]F1[-]      Show Help
+  [green]F2[-]      Script Console
+  [green]F3[-]      Pre-request / Post-response Scripts
+  [green]F4[-]      Generate Script (curl/grpcurl/ghz)
   [green]F5[-]      Send Request
   [green]F6[-]      Clear Form
   [green]F7[-]      Focus History Panel
   [green]F8[-]      Save Request to Collection
   [green]F9[-]      Focus Collections Panel
-  [green]F10[-]     Environment Variables
+  [green]F10[-]     Environments and Variables
   [green]F12[-]     Switch HTTP/gRPC Mode
 
 [cyan]Navigation:[-]
   [green]Ctrl+E[-]  Toggle Explorer Panel
   [green]Ctrl+F[-]  Search Collections (Telescope)
+  [green]Ctrl+P[-]  Paste curl/grpcurl Command
+  [green]Ctrl+O[-]  Switch Workspace
+  [green]Ctrl+R[-]  Resolved Variables Inspector
+  [green]Ctrl+S[-]  Settings
   [green]Tab[-]     Navigate between fields
   [green]Esc[-]     Close modals/popups
 
@@ -258,22 +343,50 @@ func (a *App) Init() {
   [green]Ctrl+C[-]  Copy (selected text or focused field)
   [green]Ctrl+Q[-]  Quit Application
 
-[cyan]Environment Variables Modal (F10):[-]
-  [green]a[-]       Add new variable
-  [green]e[-]       Edit selected variable
-  [green]d[-]       Delete selected variable
+[cyan]Collections Panel (F9):[-]
+  [green]n[-]       New folder
+  [green]r[-]       Run selected folder/request
+  [green]t[-]       Edit delay, captures and assertions
+  [green]i[-]       Import Postman/OpenAPI/HAR/.http file
+  [green]x[-]       Export selected folder (Postman/.http)
+  [green]v[-]       Global (root), folder or request variables
+  [green]Del[-]     Delete selected item
+
+[cyan]History Panel (F7):[-]
+  [green]Space[-]   Mark entry for export
+  [green]x[-]       Export marked (or selected) entries as HAR
+
+[cyan]Environments Modal (F10):[-]
+  [green]Enter[-]   Activate environment
+  [green]n/c/r/d[-] New, clone, rename, delete env
+  [green]l[-]       Link .env files and OS variables
+  [green]Tab[-]     Switch to the variables list
+  [green]a/e/d[-]   Add, edit, delete variable
+  [green]c[-]       Add a shell-command variable
+  [green]s/v[-]     Mark variable secret, reveal secrets
   [green]Esc[-]     Close modal
 
 [cyan]Using Variables:[-]
   Use [green]{{VAR_NAME}}[-] in URL, headers, body, or metadata.
   Example: [green]{{BASE_URL}}/api/users[-]
+  Precedence: global < folder < environment < request
+  Undefined variables are listed before sending.
+  Built-in: [green]{{$uuid}}[-] [green]{{$timestamp}}[-] [green]{{$isoTimestamp}}[-]
+  [green]{{$randomInt(1,100)}}[-] [green]{{$randomString(8)}}[-]
+  [green]{{$base64(user:pass)}}[-] [green]{{$env(HOME)}}[-]
+
+[cyan]Scripts (F3, JavaScript):[-]
+  [green]env[-]       get/set/unset/replace variables
+  [green]request[-]   edit before sending (pre-request)
+  [green]response[-]  status, code, headers, body, json()
+  [green]crypto[-], [green]http.request[-], [green]sleep[-], [green]console.log[-] (F2)
 
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]
               Press [green]Esc[-] to close this help
 [yellow]━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━[-]`)
 	helpText.SetBorder(true).SetTitle(" Help (F1) ")
 
-	a.rootPages.AddPage("help", a.createModal(helpText, 55, 30), true, false)
+	a.rootPages.AddPage("help", a.createModal(helpText, 55, 65), true, false)
 
 	// Set global key bindings for the application.
 	// Mengatur key bindings global untuk aplikasi.
@@ -313,7 +426,7 @@ func (a *App) Init() {
 				a.app.SetFocus(a.collectionsTree)
 				return nil
 			}
-			if a.rootPages.HasPage("help") {
+			if front, _ := a.rootPages.GetFrontPage(); front == "help" {
 				a.rootPages.HidePage("help")
 				return nil
 			}
@@ -323,6 +436,18 @@ func (a *App) Init() {
 		case tcell.KeyCtrlF:
 			a.showCollectionSearchModal()
 			return nil
+		case tcell.KeyCtrlP:
+			a.showPasteCommandModal()
+			return nil
+		case tcell.KeyCtrlO:
+			a.showWorkspaceModal()
+			return nil
+		case tcell.KeyCtrlR:
+			a.showResolvedVariablesModal()
+			return nil
+		case tcell.KeyCtrlS:
+			a.showSettingsModal()
+			return nil
 		case tcell.KeyCtrlQ:
 			a.app.Stop()
 			return nil
@@ -331,6 +456,12 @@ func (a *App) Init() {
 			// Menyalin teks dari widget yang sedang fokus ke clipboard.
 			a.copyToClipboard()
 			return nil
+		case tcell.KeyF2:
+			a.showConsoleModal()
+			return nil
+		case tcell.KeyF3:
+			a.showScriptsModal()
+			return nil
 		case tcell.KeyF4:
 			a.showGenerateScriptModal()
 			return nil
@@ -343,6 +474,7 @@ func (a *App) Init() {
 		return event
 	})
 
+	a.applySettings()
 	a.app.SetRoot(a.appLayout, true)
 	a.app.SetFocus(a.urlInput)
 }
@@ -360,6 +492,10 @@ func (a *App) createHeaderBar() *tview.Flex {
 	grpcSendBtn := tview.NewButton("Send (F5)").SetSelectedFunc(a.sendGrpcRequest)
 	genScriptBtn := tview.NewButton("Gen Script (F4)").SetSelectedFunc(a.showGenerateScriptModal)
 	explorerBtn := tview.NewButton("Explorer (Ctrl+E)").SetSelectedFunc(a.toggleExplorerPanel)
+	a.workspaceBtn = tview.NewButton("").SetSelectedFunc(a.showWorkspaceModal)
+	a.updateWorkspaceButton()
+	a.envDropdown = tview.NewDropDown().SetLabel("Env: ")
+	a.refreshEnvDropdown()
 
 	// The header is rebuilt whenever the page changes.
 	// Header di-render ulang setiap kali page berubah.
@@ -373,12 +509,16 @@ func (a *App) createHeaderBar() *tview.Flex {
 				AddItem(clearBtn, 0, 1, false).
 				AddItem(saveBtn, 0, 1, false).
 				AddItem(explorerBtn, 0, 1, false).
+				AddItem(a.workspaceBtn, 0, 1, false).
+				AddItem(a.envDropdown, 0, 1, false).
 				AddItem(switchModeBtn, 0, 1, false)
 		} else {
 			header.AddItem(grpcSendBtn, 0, 1, false).
 				AddItem(genScriptBtn, 0, 1, false).
 				AddItem(saveBtn, 0, 1, false).
 				AddItem(explorerBtn, 0, 1, false).
+				AddItem(a.workspaceBtn, 0, 1, false).
+				AddItem(a.envDropdown, 0, 1, false).
 				AddItem(switchModeBtn, 0, 1, false)
 		}
 	})
@@ -390,6 +530,8 @@ func (a *App) createHeaderBar() *tview.Flex {
 		AddItem(clearBtn, 0, 1, false).
 		AddItem(saveBtn, 0, 1, false).
 		AddItem(explorerBtn, 0, 1, false).
+		AddItem(a.workspaceBtn, 0, 1, false).
+		AddItem(a.envDropdown, 0, 1, false).
 		AddItem(switchModeBtn, 0, 1, false)
 
 	return header
@@ -480,7 +622,7 @@ func (a *App) generateGrpcBodyTemplate(fullMethodName, existingBody string) {
 		}
 
 		mergedMap := buildTemplateMap(newReqType, existingData)
-		jsonTemplate, err := json.MarshalIndent(mergedMap, "", "  ")
+		jsonTemplate, err := json.MarshalIndent(mergedMap, "", settings.jsonIndent())
 		if err != nil {
 			log.Printf("ERROR: could not marshal template: %v", err)
 			return
@@ -560,7 +702,7 @@ func (a *App) grpcConnect(onSuccess func()) {
 			a.grpcConn.Close()
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), settings.grpcDialTimeout())
 		defer cancel()
 
 		// Using DialContext to establish connection immediately for reflection.
@@ -623,9 +765,15 @@ func (a *App) grpcConnect(onSuccess func()) {
 	}()
 }
 
-// sendGrpcRequest sends a gRPC request using the dynamic stub.
-// sendGrpcRequest mengirimkan request gRPC menggunakan dynamic stub.
+// sendGrpcRequest sends the gRPC request after checking that all of its variables resolve.
+// sendGrpcRequest mengirim request gRPC setelah memeriksa bahwa semua variabelnya ter-resolve.
 func (a *App) sendGrpcRequest() {
+	a.checkUnresolvedVariables("grpc", a.doSendGrpcRequest)
+}
+
+// doSendGrpcRequest sends a gRPC request using the dynamic stub.
+// doSendGrpcRequest mengirimkan request gRPC menggunakan dynamic stub.
+func (a *App) doSendGrpcRequest() {
 	if a.grpcConn == nil {
 		a.grpcStatusText.SetText("[red]Not connected to any server.")
 		return
@@ -638,95 +786,47 @@ func (a *App) sendGrpcRequest() {
 	a.grpcStatusText.SetText(fmt.Sprintf("[yellow]Sending request to %s...", a.grpcCurrentService))
 	a.grpcResponseView.SetText("", true)
 
-	go func() {
-		parts := strings.SplitN(a.grpcCurrentService, "/", 2)
-		if len(parts) != 2 {
-			log.Printf("ERROR: Invalid gRPC service/method format: %s", a.grpcCurrentService)
-			a.app.QueueUpdateDraw(func() {
-				a.grpcStatusText.SetText(fmt.Sprintf("[red]Invalid service/method format: %s", a.grpcCurrentService))
-			})
-			return
-		}
-		serviceName, methodName := parts[0], parts[1]
-
-		sd, err := a.grpcReflectClient.ResolveService(serviceName)
-		if err != nil {
-			log.Printf("ERROR: Failed to resolve gRPC service '%s': %v", serviceName, err)
-			a.app.QueueUpdateDraw(func() {
-				a.grpcStatusText.SetText(fmt.Sprintf("[red]Error resolving service '%s': %v", serviceName, err))
-			})
-			return
-		}
-		md := sd.FindMethodByName(methodName)
-		if md == nil {
-			log.Printf("ERROR: gRPC method '%s' not found in service '%s'", methodName, serviceName)
-			a.app.QueueUpdateDraw(func() {
-				a.grpcStatusText.SetText(fmt.Sprintf("[red]Method '%s' not found in service '%s'", methodName, serviceName))
-			})
-			return
-		}
-
-		req := md.GetInputType()
-		dynMsg := dynamic.NewMessage(req)
-		// Replace environment variables in body
-		bodyText := a.replaceVariables(a.grpcRequestBody.GetText())
-		if bodyText != "" {
-			if err := dynMsg.UnmarshalJSON([]byte(bodyText)); err != nil {
-				log.Printf("ERROR: Failed to unmarshal gRPC request body JSON: %v", err)
-				a.app.QueueUpdateDraw(func() {
-					a.grpcStatusText.SetText(fmt.Sprintf("[red]Error parsing request body JSON: %v", err))
-				})
-				return
-			}
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	// Reuse the connection established by grpcConnect for this send.
+	// Menggunakan ulang koneksi yang dibuat oleh grpcConnect untuk pengiriman ini.
+	req := a.currentGrpcRequest(a.grpcCurrentService)
+	sv := newScriptVariables(a.activeVariables())
+	sv.commands = a.activeCommands()
+	scopes := a.collectionScopes(a.grpcSource)
+	pool := newGrpcSessionPool()
+	borrowed := &grpcSession{
+		conn:          a.grpcConn,
+		reflectClient: a.grpcReflectClient,
+		stub:          a.grpcStub,
+	}
+	borrowedServer := substituteVariables(req.GrpcServer, mergeScopes(a.editorScopes("grpc")))
+	pool.sessions[borrowedServer] = borrowed
 
-		// Replace environment variables in metadata
-		metaText := a.replaceVariables(a.grpcRequestMeta.GetText())
-		if metaText != "" {
-			var metaMap map[string]string
-			if err := json.Unmarshal([]byte(metaText), &metaMap); err != nil {
-				log.Printf("ERROR: Failed to unmarshal gRPC metadata JSON: %v", err)
-				a.app.QueueUpdateDraw(func() {
-					a.grpcStatusText.SetText(fmt.Sprintf("[red]Error parsing metadata JSON: %v", err))
-				})
-				return
-			}
-			ctx = metadata.NewOutgoingContext(ctx, metadata.New(metaMap))
+	go func() {
+		console := &scriptConsole{}
+		result := runRequest(pool, req, scopes, sv, console)
+		// A script or command variable may point the request at another server, which the pool
+		// dials itself. Those connections are closed; the one from grpcConnect stays open. /
+		// Script atau command variable dapat mengarahkan request ke server lain, yang di-dial
+		// sendiri oleh pool. Koneksi tersebut ditutup; koneksi dari grpcConnect tetap terbuka.
+		if pool.sessions[borrowedServer] == borrowed {
+			delete(pool.sessions, borrowedServer)
 		}
-
-		log.Printf("INFO: Invoking gRPC method: %s", a.grpcCurrentService)
-		start := time.Now()
-		resp, err := a.grpcStub.InvokeRpc(ctx, md, dynMsg)
-		duration := time.Since(start)
+		pool.Close()
 
 		a.app.QueueUpdateDraw(func() {
-			if err != nil {
-				log.Printf("ERROR: gRPC InvokeRpc failed for %s: %v", a.grpcCurrentService, err)
-				a.grpcStatusText.SetText(fmt.Sprintf("[red]RPC Error: %v", err))
-				a.grpcResponseView.SetText(fmt.Sprintf("%v", err), true)
+			a.applyScriptResults(sv, console)
+			if result.Error != nil {
+				log.Printf("ERROR: gRPC request failed for %s: %v", req.GrpcMethod, result.Error)
+				a.grpcStatusText.SetText(fmt.Sprintf("[red]RPC Error: %v", result.Error))
+				a.grpcResponseView.SetText(fmt.Sprintf("%v", result.Error), true)
 				return
 			}
-
-			dynResp, ok := resp.(*dynamic.Message)
-			if !ok {
-				log.Printf("ERROR: Unexpected gRPC response type: %T", resp)
-				a.grpcStatusText.SetText(fmt.Sprintf("[red]Internal Error: Unexpected response type %T", resp))
-				a.grpcResponseView.SetText(fmt.Sprintf("Could not format response: %v", resp), true)
-				return
+			status := fmt.Sprintf("[green]Success![-] | Duration: [cyan]%v[-]", result.Duration)
+			if result.ScriptError != nil {
+				status += fmt.Sprintf(" | [red]%v[-]", result.ScriptError)
 			}
-			respJSON, err := dynResp.MarshalJSONIndent()
-			if err != nil {
-				log.Printf("ERROR: Failed to marshal gRPC response JSON: %v", err)
-				a.grpcStatusText.SetText(fmt.Sprintf("[red]Error formatting response JSON: %v", err))
-				a.grpcResponseView.SetText(fmt.Sprintf("Could not format response JSON: %v", err), true)
-				return
-			}
-			log.Printf("INFO: gRPC call to %s successful. Duration: %v", a.grpcCurrentService, duration)
-			a.grpcStatusText.SetText(fmt.Sprintf("[green]Success![-] | Duration: [cyan]%v[-]", duration))
-			a.grpcResponseView.SetText(string(respJSON), true)
+			a.grpcStatusText.SetText(status)
+			a.grpcResponseView.SetText(string(result.ResponseBody), true)
 		})
 	}()
 
@@ -739,8 +839,7 @@ func (a *App) sendGrpcRequest() {
 		Body:         a.grpcRequestBody.GetText(),
 		Time:         time.Now(),
 	}
-	a.history = append([]Request{historyReq}, a.history...)
-	a.updateHistoryView()
+	a.addHistory(historyReq)
 }
 
 // loadRequestFromHistory loads a selected request from the history list into the UI.
@@ -962,14 +1061,7 @@ func (a *App) showCollectionSearchModal() {
 	// Handle selection from the results list.
 	resultsList.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
 		if index < len(a.collectionMatchedNodes) {
-			node := a.collectionMatchedNodes[index]
-			if node.Request != nil {
-				if node.Request.Type == "grpc" {
-					a.loadGrpcRequest(*node.Request)
-				} else {
-					a.loadRequest(*node.Request)
-				}
-			}
+			a.openCollectionRequest(a.collectionMatchedNodes[index])
 			a.rootPages.RemovePage("collectionSearchModal")
 		}
 	})
@@ -992,46 +1084,9 @@ func (a *App) showCollectionSearchModal() {
 func (a *App) saveCurrentRequest(name string, requestType string) {
 	var requestData *Request
 	if requestType == "grpc" {
-		requestData = &Request{
-			Name:         name,
-			Type:         "grpc",
-			GrpcServer:   a.grpcServerInput.GetText(),
-			GrpcMethod:   a.grpcCurrentService,
-			GrpcMetadata: a.grpcRequestMeta.GetText(),
-			Body:         a.grpcRequestBody.GetText(),
-			Time:         time.Now(),
-		}
+		requestData = a.currentGrpcRequest(name)
 	} else {
-		_, method := a.methodDrop.GetCurrentOption()
-		authTypeIndex, _ := a.authType.GetCurrentOption()
-		url := a.urlInput.GetText()
-		headersText := a.headersText.GetText()
-		body := a.bodyText.GetText()
-		authToken := a.authToken.GetText()
-		authUser := a.authUser.GetText()
-		authPass := a.authPass.GetText()
-
-		headers := make(map[string]string)
-		if headersText != "" {
-			if err := json.Unmarshal([]byte(headersText), &headers); err != nil {
-				log.Printf("WARN: Headers JSON is invalid, will be saved as raw text: %v", err)
-			}
-		}
-
-		requestData = &Request{
-			Name:       name,
-			Type:       "http",
-			Method:     method,
-			URL:        url,
-			Headers:    headers,
-			HeadersRaw: headersText, // Always save raw text / Selalu simpan teks mentah
-			AuthType:   authTypeIndex,
-			AuthToken:  authToken,
-			AuthUser:   authUser,
-			AuthPass:   authPass,
-			Body:       body,
-			Time:       time.Now(),
-		}
+		requestData = a.currentHttpRequest(name)
 	}
 
 	newNode := &CollectionNode{
@@ -1067,6 +1122,57 @@ func (a *App) saveCurrentRequest(name string, requestType string) {
 	a.flattenCollections()
 }
 
+// currentHttpRequest gathers the HTTP view into a Request without resolving variables.
+// currentHttpRequest mengumpulkan isi view HTTP menjadi Request tanpa me-resolve variabel.
+func (a *App) currentHttpRequest(name string) *Request {
+	_, method := a.methodDrop.GetCurrentOption()
+	authTypeIndex, _ := a.authType.GetCurrentOption()
+	headersText := a.headersText.GetText()
+
+	headers := make(map[string]string)
+	if headersText != "" {
+		if err := json.Unmarshal([]byte(headersText), &headers); err != nil {
+			log.Printf("WARN: Headers JSON is invalid, will be saved as raw text: %v", err)
+		}
+	}
+
+	return &Request{
+		Name:               name,
+		Type:               "http",
+		Method:             method,
+		URL:                a.urlInput.GetText(),
+		Headers:            headers,
+		HeadersRaw:         headersText, // Always save raw text / Selalu simpan teks mentah
+		AuthType:           authTypeIndex,
+		AuthToken:          a.authToken.GetText(),
+		AuthUser:           a.authUser.GetText(),
+		AuthPass:           a.authPass.GetText(),
+		AuthSecret:         a.authSecret.IsChecked(),
+		Body:               a.bodyText.GetText(),
+		Time:               time.Now(),
+		PreRequestScript:   a.httpScripts.pre,
+		PostResponseScript: a.httpScripts.post,
+		Variables:          sourceVariables(a.httpSource),
+	}
+}
+
+// currentGrpcRequest gathers the gRPC view into a Request without resolving variables.
+// currentGrpcRequest mengumpulkan isi view gRPC menjadi Request tanpa me-resolve variabel.
+func (a *App) currentGrpcRequest(name string) *Request {
+	return &Request{
+		Name:               name,
+		Type:               "grpc",
+		GrpcServer:         a.grpcServerInput.GetText(),
+		GrpcMethod:         a.grpcCurrentService,
+		GrpcMetadata:       a.grpcRequestMeta.GetText(),
+		Body:               a.grpcRequestBody.GetText(),
+		Time:               time.Now(),
+		PreRequestScript:   a.grpcScripts.pre,
+		PostResponseScript: a.grpcScripts.post,
+		Variables:          sourceVariables(a.grpcSource),
+	}
+}
+
 // populateCollectionsTree rebuilds the entire collections tree view from the data model.
 // populateCollectionsTree membangun kembali seluruh tree view Collections dari data model.
 func (a *App) populateCollectionsTree() {
@@ -1098,7 +1204,9 @@ func (a *App) flattenCollections() {
 func (a *App) addTreeNodes(parent *tview.TreeNode, children []*CollectionNode) {
 	for _, childData := range children {
 		var icon string
-		if childData.IsFolder {
+		if childData.SourceFile != "" {
+			icon = "📄" // Live .http file / File .http live
+		} else if childData.IsFolder {
 			icon = "📁"
 		} else if childData.Request != nil && childData.Request.Type == "grpc" {
 			icon = "🔌" // gRPC indicator / Penanda gRPC
@@ -1159,6 +1267,21 @@ func getAuthTypeIndex(authType string) int {
 	}
 }
 
+// getAuthTypeName converts an auth type index back to its string name.
+// getAuthTypeName mengkonversi index auth type kembali ke nama string-nya.
+func getAuthTypeName(index int) string {
+	switch index {
+	case 1:
+		return "Bearer Token"
+	case 2:
+		return "Basic Auth"
+	case 3:
+		return "API Key"
+	default:
+		return "No Auth"
+	}
+}
+
 // updateAuthPanel dynamically changes the authentication input fields based on the selected auth type.
 // updateAuthPanel secara dinamis mengubah field input otentikasi berdasarkan auth type yang dipilih.
 func (a *App) updateAuthPanel(authType int) {
@@ -1177,18 +1300,21 @@ func (a *App) updateAuthPanel(authType int) {
 		// Reset label jika sebelumnya diubah oleh API Key.
 		a.authToken.SetLabel("Token: ")
 		a.authPanel.AddItem(a.authToken, 0, 1, false)
+		a.authPanel.AddItem(a.authSecret, 9, 0, false)
 
 	case 2: // Basic Auth
 		basicFlex := tview.NewFlex()
 		basicFlex.AddItem(a.authUser, 0, 1, false)
 		basicFlex.AddItem(a.authPass, 0, 1, false)
 		a.authPanel.AddItem(basicFlex, 0, 1, false)
+		a.authPanel.AddItem(a.authSecret, 9, 0, false)
 
 	case 3: // API Key
 		// Reuse authToken field for API Key to persist the value.
 		// Menggunakan kembali field authToken untuk API Key agar nilainya tersimpan.
 		a.authToken.SetLabel("API Key: ")
 		a.authPanel.AddItem(a.authToken, 0, 1, false)
+		a.authPanel.AddItem(a.authSecret, 9, 0, false)
 	}
 }
 
@@ -1204,47 +1330,47 @@ func (a *App) createModal(p tview.Primitive, width, height int) tview.Primitive
 		AddItem(nil, 0, 1, false)
 }
 
-// sendRequest gathers data from the HTTP UI, calls the HTTP client, and updates the UI with the response.
-// sendRequest mengumpulkan data dari UI HTTP, memanggil HTTP client, dan memperbarui UI dengan response.
+// sendRequest sends the HTTP request after checking that all of its variables resolve.
+// sendRequest mengirim request HTTP setelah memeriksa bahwa semua variabelnya ter-resolve.
 func (a *App) sendRequest() {
-	_, method := a.methodDrop.GetCurrentOption()
-	_, authType := a.authType.GetCurrentOption()
-
-	// Replace environment variables in URL and body
-	url := a.replaceVariables(a.urlInput.GetText())
-	body := a.replaceVariables(a.bodyText.GetText())
-	authToken := a.replaceVariables(a.authToken.GetText())
-
-	requestData := HttpRequestData{
-		Method:    method,
-		URL:       url,
-		Body:      body,
-		AuthType:  authType,
-		AuthToken: authToken,
-		AuthUser:  a.authUser.GetText(),
-		AuthPass:  a.authPass.GetText(),
-		Headers:   make(map[string]string),
-	}
+	a.checkUnresolvedVariables("http", a.doSendRequest)
+}
 
-	if requestData.URL == "" {
+// doSendRequest gathers data from the HTTP UI, calls the HTTP client, and updates the UI with the response.
+// doSendRequest mengumpulkan data dari UI HTTP, memanggil HTTP client, dan memperbarui UI dengan response.
+func (a *App) doSendRequest() {
+	req := a.currentHttpRequest("")
+	if req.URL == "" {
 		a.statusText.SetText("[red]Error: URL is required")
 		return
 	}
 
-	headersJSON := a.replaceVariables(a.headersText.GetText())
-	if headersJSON != "" {
-		if err := json.Unmarshal([]byte(headersJSON), &requestData.Headers); err != nil {
-			a.statusText.SetText(fmt.Sprintf("[red]Error parsing headers: %v", err))
-			return
-		}
-	}
-
+	sv := newScriptVariables(a.activeVariables())
+	sv.commands = a.activeCommands()
+	scopes := a.collectionScopes(a.httpSource)
 	a.statusText.SetText("[yellow]Sending request...")
 
 	go func() {
-		respData := doHttpRequest(requestData)
+		console := &scriptConsole{}
+		respData := runRequest(nil, req, scopes, sv, console)
 
 		a.app.QueueUpdateDraw(func() {
+			a.applyScriptResults(sv, console)
+			if respData.HttpData != nil {
+				var record *ResponseRecord
+				if respData.Error == nil {
+					record = &ResponseRecord{
+						StartedAt:  respData.StartedAt,
+						Duration:   respData.Duration,
+						Status:     respData.Status,
+						StatusCode: respData.StatusCode,
+						Headers:    respData.ResponseHeaders,
+						Body:       respData.ResponseBody,
+					}
+				}
+				a.addHttpHistory(*respData.HttpData, record, req.AuthSecret)
+			}
+
 			if respData.Error != nil {
 				a.statusText.SetText(fmt.Sprintf("[red]Error: %v", respData.Error))
 				a.responseText.SetText(fmt.Sprintf("Error: %v", respData.Error), true)
@@ -1258,22 +1384,27 @@ func (a *App) sendRequest() {
 				statusColor = "[yellow]"
 			}
 
-			a.statusText.SetText(fmt.Sprintf("%s%s[-] | Duration: [cyan]%v[-]",
-				statusColor, respData.Status, respData.Duration))
+			status := fmt.Sprintf("%s%s[-] | Duration: [cyan]%v[-]", statusColor, respData.Status, respData.Duration)
+			if respData.ScriptError != nil {
+				status += fmt.Sprintf(" | [red]%v[-]", respData.ScriptError)
+			}
+			a.statusText.SetText(status)
 
 			var formattedBody bytes.Buffer
-			bodyToDisplay := respData.Body
-			if err := json.Indent(&formattedBody, respData.Body, "", "  "); err == nil {
-				bodyToDisplay = formattedBody.Bytes()
+			bodyToDisplay := respData.ResponseBody
+			if settings.FormatResponses {
+				if err := json.Indent(&formattedBody, respData.ResponseBody, "", settings.jsonIndent()); err == nil {
+					bodyToDisplay = formattedBody.Bytes()
+				}
 			}
 
 			var responseBuilder strings.Builder
 			responseBuilder.WriteString(fmt.Sprintf("[yellow]Status:[-] %s%s[-]\n", statusColor, respData.Status))
 			responseBuilder.WriteString(fmt.Sprintf("[yellow]Duration:[-] [cyan]%v[-]\n", respData.Duration))
-			responseBuilder.WriteString(fmt.Sprintf("[yellow]Content-Length:[-] %d bytes\n\n", len(respData.Body)))
+			responseBuilder.WriteString(fmt.Sprintf("[yellow]Content-Length:[-] %d bytes\n\n", len(respData.ResponseBody)))
 			responseBuilder.WriteString("[yellow]Headers:[-]\n")
 
-			for k, v := range respData.Headers {
+			for k, v := range respData.ResponseHeaders {
 				responseBuilder.WriteString(fmt.Sprintf("  [cyan]%s:[-] %s\n", k, strings.Join(v, ", ")))
 			}
 
@@ -1282,39 +1413,84 @@ func (a *App) sendRequest() {
 			a.responseText.SetText(responseBuilder.String(), true)
 		})
 	}()
+}
 
+// addHttpHistory records a sent HTTP request and its response, if any, at the top of the history list.
+// addHttpHistory mencatat request HTTP yang terkirim beserta response-nya, jika ada, di bagian atas daftar History.
+func (a *App) addHttpHistory(requestData HttpRequestData, response *ResponseRecord, authSecret bool) {
 	historyReq := Request{
-		Method:    requestData.Method,
-		URL:       requestData.URL,
-		Headers:   requestData.Headers,
-		Body:      requestData.Body,
-		Time:      time.Now(),
-		Type:      "http",
-		AuthType:  getAuthTypeIndex(requestData.AuthType),
-		AuthToken: requestData.AuthToken,
-		AuthUser:  requestData.AuthUser,
-		AuthPass:  requestData.AuthPass,
-	}
-	a.history = append([]Request{historyReq}, a.history...)
+		Method:     requestData.Method,
+		URL:        requestData.URL,
+		Headers:    requestData.Headers,
+		Body:       requestData.Body,
+		Time:       time.Now(),
+		Type:       "http",
+		AuthType:   getAuthTypeIndex(requestData.AuthType),
+		AuthToken:  requestData.AuthToken,
+		AuthUser:   requestData.AuthUser,
+		AuthPass:   requestData.AuthPass,
+		AuthSecret: authSecret,
+		Response:   response,
+	}
+	a.addHistory(historyReq)
+}
 
+// addHistory puts req at the top of the history, dropping the oldest entries beyond the
+// configured history size. /
+// addHistory menaruh req di posisi teratas History, dan membuang entri terlama yang melebihi
+// ukuran History yang dikonfigurasi.
+func (a *App) addHistory(req Request) {
+	a.history = append([]Request{req}, a.history...)
+	if settings.HistorySize > 0 && len(a.history) > settings.HistorySize {
+		a.history = a.history[:settings.HistorySize]
+	}
 	a.updateHistoryView()
 }
 
 // updateHistoryView clears and repopulates the history list view.
 // updateHistoryView membersihkan dan mengisi ulang list view History.
+// Marks are cleared because the indexes shift when a new entry is added. /
+// Tanda dihapus karena index bergeser ketika entri baru ditambahkan.
 func (a *App) updateHistoryView() {
 	a.historyList.Clear()
-	for i, req := range a.history {
-		var title string
-		if req.Type == "grpc" {
-			title = fmt.Sprintf("[gRPC] %s (%s)", req.Name, req.Time.Format("15:04:05"))
-		} else {
-			title = fmt.Sprintf("[%s] %s (%s)", req.Method, req.URL, req.Time.Format("15:04:05"))
-		}
+	a.historyMarks = nil
+	for i := range a.history {
 		// Capture the index in a local variable to avoid closure issue.
 		// Menangkap index di variabel lokal untuk menghindari masalah closure.
 		index := i
-		a.historyList.AddItem(title, "", 0, func() { a.loadRequestFromHistory(index) })
+		a.historyList.AddItem(a.historyTitle(index), "", 0, func() { a.loadRequestFromHistory(index) })
+	}
+}
+
+// historyTitle formats the list entry for a history item, including its export mark.
+// historyTitle memformat entri list untuk sebuah item History, termasuk tanda export-nya.
+func (a *App) historyTitle(index int) string {
+	req := a.history[index]
+	var title string
+	if req.Type == "grpc" {
+		title = fmt.Sprintf("[gRPC] %s (%s)", req.Name, req.Time.Format("15:04:05"))
+	} else {
+		title = fmt.Sprintf("[%s] %s (%s)", req.Method, req.URL, req.Time.Format("15:04:05"))
+	}
+	if a.historyMarks[index] {
+		title = "* " + title
+	}
+	return title
+}
+
+// toggleHistoryMark marks or unmarks a history entry for export and moves to the next entry.
+// toggleHistoryMark menandai atau menghapus tanda entri History untuk export dan pindah ke entri berikutnya.
+func (a *App) toggleHistoryMark(index int) {
+	if index < 0 || index >= len(a.history) {
+		return
+	}
+	if a.historyMarks == nil {
+		a.historyMarks = make(map[int]bool)
+	}
+	a.historyMarks[index] = !a.historyMarks[index]
+	a.historyList.SetItemText(index, a.historyTitle(index), "")
+	if index+1 < a.historyList.GetItemCount() {
+		a.historyList.SetCurrentItem(index + 1)
 	}
 }
 
@@ -1331,7 +1507,11 @@ func (a *App) clearForm() {
 	a.authToken.SetText("")
 	a.authUser.SetText("")
 	a.authPass.SetText("")
+	a.authSecret.SetChecked(false)
+	a.applySecretMasks()
 	a.updateAuthPanel(0)
+	a.httpScripts = requestScripts{}
+	a.httpSource = nil
 }
 
 // loadRequest populates the HTTP view with data from a Request object.
@@ -1354,8 +1534,12 @@ func (a *App) loadRequest(req Request) {
 	a.authToken.SetText(req.AuthToken)
 	a.authUser.SetText(req.AuthUser)
 	a.authPass.SetText(req.AuthPass)
+	a.authSecret.SetChecked(req.AuthSecret)
+	a.applySecretMasks()
 
 	a.urlInput.SetText(req.URL)
+	a.httpScripts = requestScripts{pre: req.PreRequestScript, post: req.PostResponseScript}
+	a.httpSource = nil
 
 	// Prefer HeadersRaw (exact user input) over marshaling Headers map.
 	// Prioritaskan HeadersRaw (input user yang asli) daripada marshal Headers map.
@@ -1364,7 +1548,7 @@ func (a *App) loadRequest(req Request) {
 	} else if len(req.Headers) > 0 {
 		// Fallback for backward compatibility with old saved requests.
 		// Fallback untuk kompatibilitas dengan request yang disimpan sebelumnya.
-		headersJSON, _ := json.MarshalIndent(req.Headers, "", "  ")
+		headersJSON, _ := json.MarshalIndent(req.Headers, "", settings.jsonIndent())
 		a.headersText.SetText(string(headersJSON), false)
 	} else {
 		a.headersText.SetText("", false)
@@ -1389,10 +1573,13 @@ func (a *App) loadGrpcRequest(req Request) {
 	a.grpcMethodInput.SetText(req.GrpcMethod)
 	a.grpcRequestBody.SetText(req.Body, false)
 	a.grpcCurrentService = req.GrpcMethod
+	a.grpcScripts = requestScripts{pre: req.PreRequestScript, post: req.PostResponseScript}
+	a.grpcSource = nil
 	a.grpcStatusText.SetText(fmt.Sprintf("Loaded: [green]%s[-]", req.Name))
 
 	if req.GrpcMethod != "" {
 		a.grpcBodyCache[req.GrpcMethod] = req.Body
+		a.saveGrpcCache()
 	}
 
 	onConnectSuccess := func() {
@@ -1413,7 +1600,7 @@ func (a *App) beautifyJSON(textArea *tview.TextArea) {
 	}
 
 	var prettyJSON bytes.Buffer
-	err := json.Indent(&prettyJSON, []byte(currentText), "", "  ")
+	err := json.Indent(&prettyJSON, []byte(currentText), "", settings.jsonIndent())
 	if err != nil {
 		log.Printf("WARN: Failed to beautify JSON: %v", err)
 		return
@@ -1460,7 +1647,7 @@ func (a *App) copyToClipboard() {
 func (a *App) toggleExplorerPanel() {
 	a.explorerPanelVisible = !a.explorerPanelVisible
 	if a.explorerPanelVisible {
-		a.contentLayout.ResizeItem(a.explorerPanel, 40, 0)
+		a.contentLayout.ResizeItem(a.explorerPanel, settings.ExplorerWidth, 0)
 	} else {
 		a.contentLayout.ResizeItem(a.explorerPanel, 0, 0)
 	}
@@ -1482,115 +1669,26 @@ func (a *App) copyTextAreaToClipboard(ta *tview.TextArea) {
 	log.Printf("INFO: Copied %d bytes to clipboard", len(text))
 }
 
-// replaceVariables replaces {{variable}} placeholders in text with values from the active environment.
-// replaceVariables mengganti placeholder {{variable}} dalam teks dengan nilai dari environment aktif.
-func (a *App) replaceVariables(text string) string {
-	if len(a.environments) == 0 || a.activeEnvIndex >= len(a.environments) {
-		return text
-	}
-	env := a.environments[a.activeEnvIndex]
-	for key, value := range env.Variables {
-		placeholder := "{{" + key + "}}"
-		text = strings.ReplaceAll(text, placeholder, value)
-	}
-	return text
+// replaceVariables replaces {{variable}} placeholders in text with the variables that apply to
+// the request on page ("http" or "grpc"), for text shown to the user such as generated scripts.
+// Secret values are masked unless revealed. /
+// replaceVariables mengganti placeholder {{variable}} dalam teks dengan variabel yang berlaku
+// untuk request di page ("http" atau "grpc"), untuk teks yang ditampilkan ke user seperti script
+// yang di-generate. Nilai rahasia disamarkan kecuali sedang ditampilkan.
+func (a *App) replaceVariables(text, page string) string {
+	return substituteVariables(text, a.maskSecrets(mergeScopes(a.editorScopes(page))))
 }
 
-// showEnvironmentModal displays a modal for managing environment variables.
-// showEnvironmentModal menampilkan modal untuk mengelola variabel environment.
-func (a *App) showEnvironmentModal() {
-	env := a.environments[a.activeEnvIndex]
-
-	// Create variable list
-	varList := tview.NewList().ShowSecondaryText(true)
-	varList.SetBorder(true).SetTitle(" Variables ")
-
-	refreshList := func() {
-		varList.Clear()
-		for key, value := range env.Variables {
-			displayValue := value
-			if len(displayValue) > 30 {
-				displayValue = displayValue[:27] + "..."
-			}
-			varList.AddItem(key, displayValue, 0, nil)
-		}
-		if varList.GetItemCount() == 0 {
-			varList.AddItem("[gray]No variables", "Press 'a' to add", 0, nil)
-		}
+// substituteVariables replaces {{variable}} placeholders in text with values from vars, then
+// evaluates built-in dynamic variables such as {{$uuid}}, so their arguments can use variables. /
+// substituteVariables mengganti placeholder {{variable}} dalam teks dengan nilai dari vars, lalu
+// mengevaluasi variabel dinamis bawaan seperti {{$uuid}}, sehingga argumennya dapat memakai variabel.
+func substituteVariables(text string, vars map[string]string) string {
+	for key, value := range vars {
+		placeholder := "{{" + key + "}}"
+		text = strings.ReplaceAll(text, placeholder, value)
 	}
-	refreshList()
-
-	// Create buttons
-	addBtn := tview.NewButton("Add (a)").SetSelectedFunc(func() {
-		a.showAddVariableModal(env, refreshList)
-	})
-	editBtn := tview.NewButton("Edit (e)").SetSelectedFunc(func() {
-		if varList.GetItemCount() > 0 {
-			key, _ := varList.GetItemText(varList.GetCurrentItem())
-			if key != "[gray]No variables" {
-				a.showEditVariableModal(env, key, refreshList)
-			}
-		}
-	})
-	deleteBtn := tview.NewButton("Delete (d)").SetSelectedFunc(func() {
-		if varList.GetItemCount() > 0 {
-			key, _ := varList.GetItemText(varList.GetCurrentItem())
-			if key != "[gray]No variables" {
-				delete(env.Variables, key)
-				refreshList()
-			}
-		}
-	})
-	closeBtn := tview.NewButton("Close (Esc)").SetSelectedFunc(func() {
-		a.rootPages.RemovePage("envModal")
-	})
-
-	buttons := tview.NewFlex().
-		AddItem(addBtn, 10, 0, false).
-		AddItem(editBtn, 11, 0, false).
-		AddItem(deleteBtn, 13, 0, false).
-		AddItem(tview.NewBox(), 0, 1, false).
-		AddItem(closeBtn, 13, 0, false)
-
-	content := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(varList, 0, 1, true).
-		AddItem(buttons, 1, 0, false)
-	content.SetBorder(true).SetTitle(fmt.Sprintf(" Environment: %s ", env.Name))
-
-	// Handle keyboard shortcuts
-	varList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Rune() {
-		case 'a':
-			a.showAddVariableModal(env, refreshList)
-			return nil
-		case 'e':
-			if varList.GetItemCount() > 0 {
-				key, _ := varList.GetItemText(varList.GetCurrentItem())
-				if key != "[gray]No variables" {
-					a.showEditVariableModal(env, key, refreshList)
-				}
-			}
-			return nil
-		case 'd':
-			if varList.GetItemCount() > 0 {
-				key, _ := varList.GetItemText(varList.GetCurrentItem())
-				if key != "[gray]No variables" {
-					delete(env.Variables, key)
-					refreshList()
-				}
-			}
-			return nil
-		}
-		if event.Key() == tcell.KeyEsc {
-			a.rootPages.RemovePage("envModal")
-			return nil
-		}
-		return event
-	})
-
-	modal := a.createModal(content, 60, 20)
-	a.rootPages.AddPage("envModal", modal, true, true)
-	a.app.SetFocus(varList)
+	return expandDynamicVariables(text)
 }
 
 // showAddVariableModal displays a form to add a new variable.
@@ -1602,11 +1700,11 @@ func (a *App) showAddVariableModal(env *Environment, onSave func()) {
 	form.AddButton("Save", func() {
 		name := form.GetFormItem(0).(*tview.InputField).GetText()
 		value := form.GetFormItem(1).(*tview.InputField).GetText()
+		a.rootPages.RemovePage("addVarModal")
 		if name != "" {
 			env.Variables[name] = value
 			onSave()
 		}
-		a.rootPages.RemovePage("addVarModal")
 	})
 	form.AddButton("Cancel", func() {
 		a.rootPages.RemovePage("addVarModal")
@@ -1623,18 +1721,31 @@ func (a *App) showAddVariableModal(env *Environment, onSave func()) {
 func (a *App) showEditVariableModal(env *Environment, key string, onSave func()) {
 	form := tview.NewForm()
 	form.AddInputField("Name", key, 30, nil, nil)
-	form.AddInputField("Value", env.Variables[key], 30, nil, nil)
+	// A linked variable is edited into an override of its .env or OS value.
+	// Variabel tautan diedit menjadi penimpa nilai .env atau OS-nya.
+	value, ok := env.Variables[key]
+	if !ok {
+		value = env.linkedVariables()[key]
+	}
+	form.AddInputField("Value", value, 30, nil, nil)
+	if env.isSecret(key) && !a.revealSecrets {
+		form.GetFormItem(1).(*tview.InputField).SetMaskCharacter('*')
+	}
 	form.AddButton("Save", func() {
 		newName := form.GetFormItem(0).(*tview.InputField).GetText()
 		newValue := form.GetFormItem(1).(*tview.InputField).GetText()
+		a.rootPages.RemovePage("editVarModal")
 		if newName != "" {
 			if newName != key {
 				delete(env.Variables, key)
+				if env.isSecret(key) {
+					env.setSecret(key, false)
+					env.setSecret(newName, true)
+				}
 			}
 			env.Variables[newName] = newValue
 			onSave()
 		}
-		a.rootPages.RemovePage("editVarModal")
 	})
 	form.AddButton("Cancel", func() {
 		a.rootPages.RemovePage("editVarModal")
@@ -1656,17 +1767,61 @@ func (a *App) Run() error {
 // main adalah entry point dari aplikasi.
 func main() {
 	initLogger()
-	app := NewApp()
-	app.Init()
+	loadSettings()
 
-	defer func() {
-		app.saveCollections()
-		app.saveGrpcCache()
-		app.saveEnvironments()
-		log.Println("INFO: Application shutting down.")
-	}()
+	// A project workspace is given with --workspace or found from the working directory.
+	// Workspace project diberikan dengan --workspace atau dicari dari direktori kerja.
+	workspaceFlag, args, err := extractWorkspaceFlag(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+	var dir string
+	if workspaceFlag != "" {
+		if dir, err = resolveWorkspace(workspaceFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+	} else {
+		dir = findWorkspace(".")
+	}
+	setWorkspaceDir(dir)
+	if dir != "" {
+		log.Printf("INFO: Using workspace %s", dir)
+		rememberWorkspace(dir)
+	}
+
+	openFile := ""
+	if len(args) == 1 && isHTTPFilePath(args[0]) {
+		openFile = args[0]
+	} else if len(args) > 0 {
+		os.Exit(runCLI(args, os.Stdout, os.Stderr))
+	}
 
-	if err := app.Run(); err != nil {
-		panic(err)
+	app := NewApp()
+	if openFile != "" {
+		if err := app.openHTTPFile(openFile); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	app.Init()
+	app.reportLoadFailures()
+	app.confirmWorkspaceCommands()
+	app.startFileWatcher()
+
+	// Changes are saved as they happen; this final save only covers state such as the body
+	// of the selected gRPC method, and it also runs when the event loop fails.
+	// Perubahan disimpan saat terjadi; penyimpanan terakhir ini hanya mencakup state seperti
+	// body dari method gRPC yang dipilih, dan tetap dijalankan jika event loop gagal.
+	err = app.Run()
+	app.saveCollections()
+	app.saveGrpcCache()
+	app.saveEnvironments()
+	log.Println("INFO: Application shutting down.")
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
 	}
 }
@@ -1,6 +1,9 @@
 package main
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 // Request represents a single saved HTTP or gRPC request.
 // Request merepresentasikan satu request HTTP atau gRPC yang disimpan.
@@ -19,11 +22,53 @@ type Request struct {
 	AuthToken  string            `json:"auth_token,omitempty"`
 	AuthUser   string            `json:"auth_user,omitempty"`
 	AuthPass   string            `json:"auth_pass,omitempty"`
+	AuthSecret bool              `json:"auth_secret,omitempty"` // Token and password are encrypted at rest and masked / Token dan password dienkripsi saat disimpan dan disamarkan
 
 	// gRPC specific fields / Field spesifik gRPC
 	GrpcServer   string `json:"grpc_server,omitempty"`
 	GrpcMethod   string `json:"grpc_method,omitempty"`
 	GrpcMetadata string `json:"grpc_metadata,omitempty"`
+
+	// Collection runner fields / Field untuk collection runner
+	DelayMs    int         `json:"delay_ms,omitempty"` // Delay before sending when run as part of a collection / Jeda sebelum dikirim saat dijalankan sebagai bagian dari collection
+	Captures   []Capture   `json:"captures,omitempty"`
+	Assertions []Assertion `json:"assertions,omitempty"`
+
+	// JavaScript hooks run before sending and after receiving / Hook JavaScript yang dijalankan sebelum mengirim dan setelah menerima
+	PreRequestScript   string `json:"pre_request_script,omitempty"`
+	PostResponseScript string `json:"post_response_script,omitempty"`
+
+	// Request-local variables, the highest-precedence scope / Variabel lokal request, scope dengan prioritas tertinggi
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// Response recorded for history entries / Response yang direkam untuk entri History
+	Response *ResponseRecord `json:"response,omitempty"`
+}
+
+// ResponseRecord keeps the timing and response of a sent request so history can be exported as HAR.
+// ResponseRecord menyimpan waktu dan response dari request yang terkirim agar History dapat di-export sebagai HAR.
+type ResponseRecord struct {
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	Status     string        `json:"status"`
+	StatusCode int           `json:"status_code"`
+	Headers    http.Header   `json:"headers,omitempty"`
+	Body       []byte        `json:"body,omitempty"`
+}
+
+// Capture extracts a value from a response and stores it as a variable for the following requests.
+// Capture mengambil sebuah nilai dari response dan menyimpannya sebagai variabel untuk request berikutnya.
+type Capture struct {
+	Variable string `json:"variable"`
+	Source   string `json:"source"` // e.g. "json.data.token", "header.X-Request-Id", "status" / mis. "json.data.token", "header.X-Request-Id", "status"
+}
+
+// Assertion checks a value extracted from a response against an expected value.
+// Assertion memeriksa nilai yang diambil dari response terhadap nilai yang diharapkan.
+type Assertion struct {
+	Source   string `json:"source"`
+	Operator string `json:"operator"` // "==", "!=", "<", ">", "contains", "exists" / "==", "!=", "<", ">", "contains", "exists"
+	Expected string `json:"expected,omitempty"`
 }
 
 // CollectionNode represents a node in the collections tree. It can be a folder or a request.
@@ -34,6 +79,14 @@ type CollectionNode struct {
 	Request  *Request          `json:"request,omitempty"`
 	Children []*CollectionNode `json:"children,omitempty"`
 	Expanded bool              `json:"-"` // Excluded from JSON serialization. / Dikecualikan dari serialisasi JSON.
+
+	// SourceFile is set on folders opened from a .http file; they are saved to that file instead.
+	// SourceFile diisi pada folder yang dibuka dari file .http; folder tersebut disimpan ke file itu.
+	SourceFile string `json:"-"`
+
+	// Variables of this folder, or the global variables on the root node.
+	// Variabel dari folder ini, atau variabel global pada node root.
+	Variables map[string]string `json:"variables,omitempty"`
 }
 
 // Environment represents a set of variables that can be used in requests.
@@ -41,4 +94,21 @@ type CollectionNode struct {
 type Environment struct {
 	Name      string            `json:"name"`
 	Variables map[string]string `json:"variables"`
+	Active    bool              `json:"active,omitempty"`  // Selected environment, kept across restarts / Environment yang dipilih, tetap tersimpan setelah restart
+	Secrets   []string          `json:"secrets,omitempty"` // Variables encrypted at rest and masked / Variabel yang dienkripsi saat disimpan dan disamarkan
+	// Linked .env files and imported OS variables, resolved below Variables /
+	// File .env yang ditautkan dan variabel OS yang di-import, di bawah Variables
+	DotenvFiles []string `json:"dotenv_files,omitempty"`
+	OSVariables []string `json:"os_variables,omitempty"`
+	// Variables whose value is the output of a shell command, run at send time /
+	// Variabel yang nilainya adalah output sebuah perintah shell, dijalankan saat request dikirim
+	Commands map[string]*CommandVariable `json:"commands,omitempty"`
+}
+
+// CommandVariable is a variable whose value is the standard output of a shell command.
+// CommandVariable adalah variabel yang nilainya adalah standard output dari sebuah perintah shell.
+type CommandVariable struct {
+	Command        string `json:"command"`
+	CacheSeconds   int    `json:"cache_seconds,omitempty"`   // Reuse the output for this long; 0 runs it on every send / Pakai ulang output selama ini; 0 menjalankannya setiap kirim
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // 0 means the default of 10 seconds / 0 berarti default 10 detik
 }
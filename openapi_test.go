@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testOpenAPIDocument = `
+openapi: 3.0.3
+info:
+  title: Pets
+servers:
+  - url: https://{region}.pets.test/v1/
+    variables:
+      region:
+        default: eu
+tags:
+  - name: pets
+components:
+  securitySchemes:
+    token:
+      type: http
+      scheme: bearer
+    key:
+      type: apiKey
+      in: header
+      name: X-Key
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        born:
+          type: string
+          format: date
+        parent:
+          $ref: '#/components/schemas/Pet'
+security:
+  - token: []
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          example: 7
+    get:
+      tags: [pets]
+      summary: Get pet
+      parameters:
+        - name: verbose
+          in: query
+          schema:
+            type: boolean
+            default: false
+        - name: X-Trace
+          in: header
+          schema:
+            type: string
+        - name: session
+          in: cookie
+    delete:
+      tags: [pets]
+      operationId: deletePet
+      security:
+        - key: []
+  /pets:
+    post:
+      tags: [pets]
+      summary: Create pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /health:
+    get:
+      security: []
+`
+
+func TestImportOpenAPI(t *testing.T) {
+	result, err := importOpenAPI([]byte(testOpenAPIDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := result.Collection
+	if root.Name != "Pets" || len(root.Children) != 2 || root.Children[0].Name != "pets" || root.Children[1].Name != "default" {
+		t.Fatalf("unexpected tree %q with %d folders", root.Name, len(root.Children))
+	}
+
+	wantVars := map[string]string{
+		"baseUrl":     "https://eu.pets.test/v1",
+		"petId":       "7",
+		"verbose":     "false",
+		"bearerToken": "",
+		"apiKey":      "",
+	}
+	if len(result.Environments) != 1 || !reflect.DeepEqual(result.Environments[0].Variables, wantVars) {
+		t.Errorf("environment variables = %v, want %v", result.Environments[0].Variables, wantVars)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the cookie parameter", result.Warnings)
+	}
+
+	pets := root.Children[0].Children
+	health := root.Children[1].Children
+	if len(pets) != 3 || len(health) != 1 {
+		t.Fatalf("got %d pet and %d default requests", len(pets), len(health))
+	}
+
+	tests := []struct {
+		name        string
+		req         *Request
+		wantName    string
+		wantMethod  string
+		wantURL     string
+		wantHeaders map[string]string
+		wantAuth    int
+	}{
+		{
+			name:        "path, query and global bearer",
+			req:         pets[1].Request,
+			wantName:    "Get pet",
+			wantMethod:  "GET",
+			wantURL:     "{{baseUrl}}/pets/{{petId}}?verbose={{verbose}}",
+			wantHeaders: map[string]string{},
+			wantAuth:    getAuthTypeIndex("Bearer Token"),
+		},
+		{
+			name:        "operation API key header",
+			req:         pets[2].Request,
+			wantName:    "deletePet",
+			wantMethod:  "DELETE",
+			wantURL:     "{{baseUrl}}/pets/{{petId}}",
+			wantHeaders: map[string]string{"X-Key": "{{apiKey}}"},
+		},
+		{
+			name:        "anonymous operation without summary",
+			req:         health[0].Request,
+			wantName:    "GET /health",
+			wantMethod:  "GET",
+			wantURL:     "{{baseUrl}}/health",
+			wantHeaders: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.Name != tt.wantName || req.Method != tt.wantMethod || req.URL != tt.wantURL {
+				t.Errorf("got %q %s %s, want %q %s %s", req.Name, req.Method, req.URL, tt.wantName, tt.wantMethod, tt.wantURL)
+			}
+			if !reflect.DeepEqual(req.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", req.Headers, tt.wantHeaders)
+			}
+			if req.AuthType != tt.wantAuth {
+				t.Errorf("auth type = %d, want %d", req.AuthType, tt.wantAuth)
+			}
+		})
+	}
+
+	create := pets[0].Request
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(create.Body), &body); err != nil {
+		t.Fatalf("create body %q: %v", create.Body, err)
+	}
+	wantBody := map[string]interface{}{"name": "string", "born": "2024-01-01"}
+	if !reflect.DeepEqual(body, wantBody) || create.Headers["Content-Type"] != "application/json" {
+		t.Errorf("create body = %v (%s), want %v", body, create.Headers["Content-Type"], wantBody)
+	}
+}
+
+func TestImportSwagger2(t *testing.T) {
+	data := []byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Legacy"},
+		"host": "legacy.test",
+		"basePath": "/api",
+		"schemes": ["http"],
+		"securityDefinitions": {"basic": {"type": "basic"}},
+		"paths": {
+			"/login": {
+				"post": {
+					"security": [{"basic": []}],
+					"parameters": [
+						{"name": "user", "in": "formData", "type": "string", "default": "admin"},
+						{"name": "avatar", "in": "formData", "type": "file"}
+					]
+				}
+			}
+		}
+	}`)
+	result, err := importOpenAPI(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := result.Collection.Children[0].Children[0].Request
+	if req.URL != "{{baseUrl}}/login" || req.Body != "user=admin" || req.Headers["Content-Type"] != "application/x-www-form-urlencoded" {
+		t.Errorf("got %s %q %v", req.URL, req.Body, req.Headers)
+	}
+	if req.AuthType != getAuthTypeIndex("Basic Auth") || req.AuthUser != "{{username}}" {
+		t.Errorf("auth = %d %q", req.AuthType, req.AuthUser)
+	}
+	if got := result.Environments[0].Variables["baseUrl"]; got != "http://legacy.test/api" {
+		t.Errorf("baseUrl = %q", got)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the file field", result.Warnings)
+	}
+}
+
+func TestIsOpenAPIDocument(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"openapi: 3.1.0\npaths: {}", true},
+		{`{"swagger": "2.0"}`, true},
+		{"swagger: 2.0", true},
+		{"openapi: 2.0", false},
+		{`{"info": {"_postman_id": "x"}}`, false},
+		{"not: [valid", false},
+	}
+	for _, tt := range tests {
+		if got := isOpenAPIDocument([]byte(tt.data)); got != tt.want {
+			t.Errorf("isOpenAPIDocument(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestImportOpenAPIWithoutPaths(t *testing.T) {
+	if _, err := importOpenAPI([]byte("openapi: 3.0.0\ninfo:\n  title: Empty\n")); err == nil {
+		t.Error("importing a document without paths succeeded, want an error")
+	}
+}
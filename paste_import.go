@@ -0,0 +1,522 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// splitShellWords splits a pasted command line into arguments the way a POSIX shell would:
+// single quotes, double quotes with backslash escapes, ANSI-C $'...' quotes (used by browser
+// "Copy as cURL") and backslash line continuations. /
+// splitShellWords memecah command line yang di-paste menjadi argumen seperti yang dilakukan shell POSIX:
+// kutip tunggal, kutip ganda dengan escape backslash, kutip ANSI-C $'...' (dipakai oleh fitur
+// "Copy as cURL" di browser) dan sambungan baris dengan backslash.
+func splitShellWords(line string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inWord := false
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && (runes[i+1] == '\n' || runes[i+1] == '\r'):
+			// Line continuation / Sambungan baris
+			i++
+			if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+				i++
+			}
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+				inWord = true
+			}
+		case r == '\'':
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			inWord = true
+			i = end
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			value, end, err := readANSIQuoted(runes, i+2)
+			if err != nil {
+				return nil, err
+			}
+			current.WriteString(value)
+			inWord = true
+			i = end
+		case r == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				current.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
+
+// indexRune returns the index of the first target in runes at or after from, or -1.
+// indexRune mengembalikan index dari target pertama di runes mulai dari from, atau -1.
+func indexRune(runes []rune, from int, target rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// readANSIQuoted reads the body of a $'...' string starting at from and returns the decoded
+// value and the index of the closing quote. /
+// readANSIQuoted membaca isi string $'...' mulai dari from dan mengembalikan nilai yang sudah
+// di-decode serta index kutip penutup.
+func readANSIQuoted(runes []rune, from int) (string, int, error) {
+	var b strings.Builder
+	for i := from; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\'' {
+			return b.String(), i, nil
+		}
+		if r != '\\' || i+1 >= len(runes) {
+			b.WriteRune(r)
+			continue
+		}
+		i++
+		switch runes[i] {
+		case 'n':
+			b.WriteRune('\n')
+		case 't':
+			b.WriteRune('\t')
+		case 'r':
+			b.WriteRune('\r')
+		case 'x':
+			if i+2 < len(runes) {
+				var v int
+				if _, err := fmt.Sscanf(string(runes[i+1:i+3]), "%02x", &v); err == nil {
+					b.WriteByte(byte(v))
+					i += 2
+					continue
+				}
+			}
+			b.WriteString(`\x`)
+		case 'u':
+			if i+4 < len(runes) {
+				var v int
+				if _, err := fmt.Sscanf(string(runes[i+1:i+5]), "%04x", &v); err == nil {
+					b.WriteRune(rune(v))
+					i += 4
+					continue
+				}
+			}
+			b.WriteString(`\u`)
+		default:
+			b.WriteRune(runes[i]) // \\, \', \" and unknown escapes / \\, \', \" dan escape yang tidak dikenal
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated $'...' quote")
+}
+
+// curlFlagsWithValue lists the curl options that consume the following argument.
+// curlFlagsWithValue berisi daftar opsi curl yang memakai argumen berikutnya.
+var curlFlagsWithValue = map[string]bool{
+	"-X": true, "--request": true, "-H": true, "--header": true,
+	"-d": true, "--data": true, "--data-raw": true, "--data-binary": true, "--data-ascii": true, "--data-urlencode": true,
+	"--json": true, "-u": true, "--user": true, "-F": true, "--form": true, "--form-string": true,
+	"--url": true, "-A": true, "--user-agent": true, "-e": true, "--referer": true, "-b": true, "--cookie": true,
+	"--oauth2-bearer": true, "-o": true, "--output": true, "-m": true, "--max-time": true,
+	"--connect-timeout": true, "-x": true, "--proxy": true, "--retry": true, "-w": true, "--write-out": true,
+	"--cacert": true, "--cert": true, "--key": true, "-c": true, "--cookie-jar": true, "-T": true, "--upload-file": true,
+	"--resolve": true, "--max-redirs": true,
+}
+
+// parseCurlCommand converts a curl command line into an HTTP request. Authorization headers
+// and -u credentials are moved to the auth settings. /
+// parseCurlCommand mengkonversi command line curl menjadi request HTTP. Header Authorization
+// dan kredensial -u dipindahkan ke pengaturan auth.
+func parseCurlCommand(command string) (*Request, []string, error) {
+	args, err := splitShellWords(strings.TrimSpace(command))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing command: %w", err)
+	}
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, nil, fmt.Errorf("not a curl command")
+	}
+
+	req := &Request{Type: "http", Headers: make(map[string]string), Time: time.Now()}
+	var warnings []string
+	var dataParts []string
+	var formFields [][2]string
+	var rawURL, method, user string
+	getMode := false
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := arg, "", false
+
+		switch {
+		case strings.HasPrefix(arg, "--") && strings.Contains(arg, "="):
+			name, value, _ = strings.Cut(arg, "=")
+			hasValue = true
+		case len(arg) > 2 && arg[0] == '-' && arg[1] != '-':
+			// Either an attached value (-XPOST, -H'X: y') or combined flags (-sSL).
+			// Bisa berupa nilai yang menempel (-XPOST, -H'X: y') atau gabungan flag (-sSL).
+			if short := arg[:2]; curlFlagsWithValue[short] {
+				name, value, hasValue = short, arg[2:], true
+			} else {
+				for _, c := range arg[1:] {
+					if curlFlagsWithValue["-"+string(c)] {
+						name = "-" + string(c)
+					}
+				}
+				if name == arg {
+					continue // Only boolean flags / Hanya flag boolean
+				}
+			}
+		}
+
+		if curlFlagsWithValue[name] && !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("option %s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "-X", "--request":
+			method = strings.ToUpper(value)
+		case "-H", "--header":
+			key, val, ok := strings.Cut(value, ":")
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("ignored malformed header %q", value))
+				continue
+			}
+			req.Headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		case "-d", "--data", "--data-ascii", "--data-binary", "--data-raw":
+			if strings.HasPrefix(value, "@") && name != "--data-raw" {
+				content, err := os.ReadFile(expandHomePath(value[1:]))
+				if err != nil {
+					warnings = append(warnings, fmt.Sprintf("could not read body file %s: %v", value[1:], err))
+					continue
+				}
+				value = string(content)
+				if name != "--data-binary" {
+					value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+				}
+			}
+			dataParts = append(dataParts, value)
+		case "--data-urlencode":
+			dataParts = append(dataParts, curlURLEncode(value))
+		case "--json":
+			dataParts = append(dataParts, value)
+			if !headersHave(req.Headers, "Content-Type") {
+				req.Headers["Content-Type"] = "application/json"
+			}
+			if !headersHave(req.Headers, "Accept") {
+				req.Headers["Accept"] = "application/json"
+			}
+		case "-F", "--form", "--form-string":
+			key, val, _ := strings.Cut(value, "=")
+			if strings.HasPrefix(val, "@") || strings.HasPrefix(val, "<") {
+				warnings = append(warnings, fmt.Sprintf("form file field '%s' skipped", key))
+				continue
+			}
+			formFields = append(formFields, [2]string{key, val})
+		case "-u", "--user":
+			user = value
+		case "--oauth2-bearer":
+			req.AuthType = getAuthTypeIndex("Bearer Token")
+			req.AuthToken = value
+		case "--url":
+			rawURL = value
+		case "-A", "--user-agent":
+			req.Headers["User-Agent"] = value
+		case "-e", "--referer":
+			req.Headers["Referer"] = value
+		case "-b", "--cookie":
+			if strings.Contains(value, "=") {
+				req.Headers["Cookie"] = value
+			} else {
+				warnings = append(warnings, "cookie file skipped")
+			}
+		case "-G", "--get":
+			getMode = true
+		case "-I", "--head":
+			method = "HEAD"
+		case "-T", "--upload-file":
+			warnings = append(warnings, "upload file skipped")
+		default:
+			if strings.HasPrefix(name, "-") {
+				continue // Transport and output options do not affect the request. / Opsi transport dan output tidak memengaruhi request.
+			}
+			if rawURL == "" {
+				rawURL = arg
+			}
+		}
+	}
+
+	if rawURL == "" {
+		return nil, nil, fmt.Errorf("no URL found in curl command")
+	}
+	if !strings.Contains(rawURL, "://") && !strings.HasPrefix(rawURL, "{{") {
+		rawURL = "http://" + rawURL
+	}
+
+	body := strings.Join(dataParts, "&")
+	switch {
+	case getMode && body != "":
+		sep := "?"
+		if strings.Contains(rawURL, "?") {
+			sep = "&"
+		}
+		rawURL += sep + body
+		body = ""
+	case len(formFields) > 0:
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		_ = w.SetBoundary("panggil-form-boundary")
+		for _, f := range formFields {
+			_ = w.WriteField(f[0], f[1])
+		}
+		_ = w.Close()
+		body = buf.String()
+		req.Headers["Content-Type"] = w.FormDataContentType()
+	case body != "" && !headersHave(req.Headers, "Content-Type"):
+		req.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+	}
+
+	switch {
+	case method != "":
+		req.Method = method
+	case getMode:
+		req.Method = "GET"
+	case body != "":
+		req.Method = "POST"
+	default:
+		req.Method = "GET"
+	}
+	req.URL = rawURL
+	req.Body = body
+
+	if user != "" {
+		req.AuthType = getAuthTypeIndex("Basic Auth")
+		req.AuthUser, req.AuthPass, _ = strings.Cut(user, ":")
+	}
+	extractAuthorizationHeader(req)
+
+	req.Name = req.Method + " " + req.URL
+	if len(req.Headers) > 0 {
+		raw, _ := json.MarshalIndent(req.Headers, "", "  ")
+		req.HeadersRaw = string(raw)
+	}
+	return req, warnings, nil
+}
+
+// curlURLEncode encodes a --data-urlencode value ("content", "=content" or "name=content").
+// curlURLEncode meng-encode nilai --data-urlencode ("content", "=content" atau "name=content").
+func curlURLEncode(value string) string {
+	name, content, ok := strings.Cut(value, "=")
+	if !ok {
+		return url.QueryEscape(value)
+	}
+	if name == "" {
+		return url.QueryEscape(content)
+	}
+	return name + "=" + url.QueryEscape(content)
+}
+
+// extractAuthorizationHeader moves a Bearer or Basic Authorization header into the auth settings.
+// extractAuthorizationHeader memindahkan header Authorization Bearer atau Basic ke pengaturan auth.
+func extractAuthorizationHeader(req *Request) {
+	for key, value := range req.Headers {
+		if !strings.EqualFold(key, "Authorization") {
+			continue
+		}
+		scheme, credentials, _ := strings.Cut(strings.TrimSpace(value), " ")
+		switch strings.ToLower(scheme) {
+		case "bearer":
+			req.AuthType = getAuthTypeIndex("Bearer Token")
+			req.AuthToken = strings.TrimSpace(credentials)
+			delete(req.Headers, key)
+		case "basic":
+			// .http files may also use the unencoded "user:pass" and "user pass" forms.
+			// File .http juga dapat memakai bentuk "user:pass" dan "user pass" yang tidak di-encode.
+			credentials = strings.TrimSpace(credentials)
+			decoded, err := base64.StdEncoding.DecodeString(credentials)
+			switch {
+			case err == nil:
+				credentials = string(decoded)
+			case strings.Contains(credentials, ":"):
+			case strings.Contains(credentials, " "):
+				credentials = strings.Replace(credentials, " ", ":", 1)
+			default:
+				return
+			}
+			user, pass, _ := strings.Cut(credentials, ":")
+			req.AuthType = getAuthTypeIndex("Basic Auth")
+			req.AuthUser, req.AuthPass = user, pass
+			delete(req.Headers, key)
+		}
+		return
+	}
+}
+
+// grpcurlFlagsWithValue lists the grpcurl options that consume the following argument.
+// grpcurlFlagsWithValue berisi daftar opsi grpcurl yang memakai argumen berikutnya.
+var grpcurlFlagsWithValue = map[string]bool{
+	"H": true, "rpc-header": true, "reflect-header": true, "d": true,
+	"import-path": true, "proto": true, "protoset": true, "protoset-out": true,
+	"cacert": true, "cert": true, "key": true, "authority": true, "servername": true, "user-agent": true,
+	"connect-timeout": true, "keepalive-time": true, "max-time": true, "max-msg-sz": true, "format": true,
+}
+
+// parseGrpcurlCommand converts a grpcurl invocation into a gRPC request. panggil discovers
+// services through server reflection, so -proto and -import-path are only reported. /
+// parseGrpcurlCommand mengkonversi pemanggilan grpcurl menjadi request gRPC. panggil menemukan
+// service melalui server reflection, sehingga -proto dan -import-path hanya dilaporkan.
+func parseGrpcurlCommand(command string) (*Request, []string, error) {
+	args, err := splitShellWords(strings.TrimSpace(command))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing command: %w", err)
+	}
+	if len(args) == 0 || args[0] != "grpcurl" {
+		return nil, nil, fmt.Errorf("not a grpcurl command")
+	}
+
+	metadata := make(map[string]string)
+	var warnings, positional, protoFiles []string
+	var body string
+	plaintext := false
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+
+		// grpcurl uses Go flag syntax: -name, --name, -name=value or -name value.
+		// grpcurl memakai sintaks flag Go: -name, --name, -name=value atau -name value.
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if grpcurlFlagsWithValue[name] && !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("option -%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "plaintext":
+			plaintext = !hasValue || value == "true"
+		case "H", "rpc-header":
+			key, val, ok := strings.Cut(value, ":")
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("ignored malformed header %q", value))
+				continue
+			}
+			metadata[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		case "d":
+			if value == "@" {
+				warnings = append(warnings, "request body read from stdin skipped")
+				continue
+			}
+			body = value
+		case "proto", "protoset":
+			protoFiles = append(protoFiles, value)
+		case "import-path":
+			// Only relevant together with -proto / Hanya relevan bersama -proto
+		case "insecure", "cacert", "cert", "key":
+			warnings = append(warnings, fmt.Sprintf("TLS option -%s ignored; panggil connects without TLS", name))
+		}
+	}
+
+	if len(protoFiles) > 0 {
+		warnings = append(warnings, fmt.Sprintf("proto files (%s) ignored; the server must support reflection", strings.Join(protoFiles, ", ")))
+	}
+	if !plaintext {
+		warnings = append(warnings, "command uses TLS; panggil connects in plaintext")
+	}
+	if len(positional) < 2 {
+		return nil, nil, fmt.Errorf("grpcurl command needs an address and a method")
+	}
+	if positional[1] == "list" || positional[1] == "describe" {
+		return nil, nil, fmt.Errorf("grpcurl '%s' does not call a method", positional[1])
+	}
+
+	// Methods may be written as pkg.Service/Method or pkg.Service.Method.
+	// Method dapat ditulis sebagai pkg.Service/Method atau pkg.Service.Method.
+	method := positional[1]
+	if !strings.Contains(method, "/") {
+		if dot := strings.LastIndex(method, "."); dot > 0 {
+			method = method[:dot] + "/" + method[dot+1:]
+		}
+	}
+
+	var pretty bytes.Buffer
+	if json.Indent(&pretty, []byte(body), "", "  ") == nil {
+		body = pretty.String()
+	}
+
+	req := &Request{
+		Type:       "grpc",
+		Name:       method,
+		GrpcServer: positional[0],
+		GrpcMethod: method,
+		Body:       body,
+		Time:       time.Now(),
+	}
+	if len(metadata) > 0 {
+		raw, _ := json.MarshalIndent(metadata, "", "  ")
+		req.GrpcMetadata = string(raw)
+	}
+	return req, warnings, nil
+}
+
+// parsePastedCommand converts a pasted curl or grpcurl command into a request.
+// parsePastedCommand mengkonversi command curl atau grpcurl yang di-paste menjadi request.
+func parsePastedCommand(command string) (*Request, []string, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("command is empty")
+	}
+	switch fields[0] {
+	case "curl":
+		return parseCurlCommand(command)
+	case "grpcurl":
+		return parseGrpcurlCommand(command)
+	}
+	return nil, nil, fmt.Errorf("only curl and grpcurl commands are supported")
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitShellWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "plain", line: "curl -s http://x", want: []string{"curl", "-s", "http://x"}},
+		{name: "single quotes", line: `curl -H 'X-A: b c'`, want: []string{"curl", "-H", "X-A: b c"}},
+		{name: "double quotes with escapes", line: `curl -d "{\"a\":\"$\"}"`, want: []string{"curl", "-d", `{"a":"$"}`}},
+		{name: "ANSI-C quotes", line: `curl --data-raw $'line1\nline2'`, want: []string{"curl", "--data-raw", "line1\nline2"}},
+		{name: "line continuation", line: "curl \\\n  -X POST \\\r\n  http://x", want: []string{"curl", "-X", "POST", "http://x"}},
+		{name: "backslash escape", line: `a\ b`, want: []string{"a b"}},
+		{name: "empty quotes", line: `curl ''`, want: []string{"curl", ""}},
+		{name: "unterminated single quote", line: `curl 'abc`, wantErr: true},
+		{name: "unterminated double quote", line: `curl "abc`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitShellWords(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitShellWords(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitShellWords(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCurlCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		wantMethod  string
+		wantURL     string
+		wantBody    string
+		wantHeaders map[string]string
+		wantAuth    int
+		wantToken   string
+		wantUser    string
+		wantPass    string
+	}{
+		{
+			name:        "simple GET",
+			command:     "curl https://api.example.com/users",
+			wantMethod:  "GET",
+			wantURL:     "https://api.example.com/users",
+			wantHeaders: map[string]string{},
+		},
+		{
+			name:        "data implies POST and form content type",
+			command:     "curl https://x/login -d user=a -d pass=b",
+			wantMethod:  "POST",
+			wantURL:     "https://x/login",
+			wantBody:    "user=a&pass=b",
+			wantHeaders: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		},
+		{
+			name:        "explicit method and JSON body",
+			command:     `curl -X PUT https://x/items/1 -H 'Content-Type: application/json' --data-raw '{"a":1}'`,
+			wantMethod:  "PUT",
+			wantURL:     "https://x/items/1",
+			wantBody:    `{"a":1}`,
+			wantHeaders: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			name:        "attached method and combined boolean flags",
+			command:     "curl -sSL -XDELETE https://x/items/1",
+			wantMethod:  "DELETE",
+			wantURL:     "https://x/items/1",
+			wantHeaders: map[string]string{},
+		},
+		{
+			name:        "get mode moves data into the query",
+			command:     "curl -G https://x/search?a=1 --data-urlencode 'q=hello world'",
+			wantMethod:  "GET",
+			wantURL:     "https://x/search?a=1&q=hello+world",
+			wantHeaders: map[string]string{},
+		},
+		{
+			name:        "json flag sets content type and accept",
+			command:     `curl --json '{"a":1}' https://x`,
+			wantMethod:  "POST",
+			wantURL:     "https://x",
+			wantBody:    `{"a":1}`,
+			wantHeaders: map[string]string{"Content-Type": "application/json", "Accept": "application/json"},
+		},
+		{
+			name:        "bearer header moves to auth",
+			command:     "curl -H 'Authorization: Bearer abc' https://x",
+			wantMethod:  "GET",
+			wantURL:     "https://x",
+			wantHeaders: map[string]string{},
+			wantAuth:    getAuthTypeIndex("Bearer Token"),
+			wantToken:   "abc",
+		},
+		{
+			name:        "basic header is decoded into auth",
+			command:     "curl -H 'Authorization: Basic dXNlcjpwYXNz' https://x",
+			wantMethod:  "GET",
+			wantURL:     "https://x",
+			wantHeaders: map[string]string{},
+			wantAuth:    getAuthTypeIndex("Basic Auth"),
+			wantUser:    "user",
+			wantPass:    "pass",
+		},
+		{
+			name:        "user flag",
+			command:     "curl -u admin:secret --url https://x",
+			wantMethod:  "GET",
+			wantURL:     "https://x",
+			wantHeaders: map[string]string{},
+			wantAuth:    getAuthTypeIndex("Basic Auth"),
+			wantUser:    "admin",
+			wantPass:    "secret",
+		},
+		{
+			name:        "scheme is added to a bare host",
+			command:     "curl -I localhost:8080/health",
+			wantMethod:  "HEAD",
+			wantURL:     "http://localhost:8080/health",
+			wantHeaders: map[string]string{},
+		},
+		{
+			name:        "variable URL is kept",
+			command:     "curl {{baseUrl}}/users -A panggil",
+			wantMethod:  "GET",
+			wantURL:     "{{baseUrl}}/users",
+			wantHeaders: map[string]string{"User-Agent": "panggil"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _, err := parseCurlCommand(tt.command)
+			if err != nil {
+				t.Fatalf("parseCurlCommand(%q) error: %v", tt.command, err)
+			}
+			if req.Type != "http" || req.Method != tt.wantMethod || req.URL != tt.wantURL || req.Body != tt.wantBody {
+				t.Errorf("got %s %s %q (type %s), want %s %s %q", req.Method, req.URL, req.Body, req.Type, tt.wantMethod, tt.wantURL, tt.wantBody)
+			}
+			if !reflect.DeepEqual(req.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", req.Headers, tt.wantHeaders)
+			}
+			if req.AuthType != tt.wantAuth || req.AuthToken != tt.wantToken || req.AuthUser != tt.wantUser || req.AuthPass != tt.wantPass {
+				t.Errorf("auth = %d %q %q %q, want %d %q %q %q", req.AuthType, req.AuthToken, req.AuthUser, req.AuthPass, tt.wantAuth, tt.wantToken, tt.wantUser, tt.wantPass)
+			}
+		})
+	}
+}
+
+func TestParseCurlCommandForm(t *testing.T) {
+	req, warnings, err := parseCurlCommand("curl -F name=panggil -F file=@photo.png https://x/upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Method != "POST" || !strings.HasPrefix(req.Headers["Content-Type"], "multipart/form-data; boundary=") {
+		t.Errorf("got %s with content type %q", req.Method, req.Headers["Content-Type"])
+	}
+	if !strings.Contains(req.Body, `name="name"`) || strings.Contains(req.Body, "photo.png") {
+		t.Errorf("unexpected form body %q", req.Body)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the skipped file field", warnings)
+	}
+}
+
+func TestParseCurlCommandErrors(t *testing.T) {
+	for _, command := range []string{"wget https://x", "curl -s", "curl -H", "curl 'https://x"} {
+		if _, _, err := parseCurlCommand(command); err == nil {
+			t.Errorf("parseCurlCommand(%q) succeeded, want an error", command)
+		}
+	}
+}
+
+func TestParseGrpcurlCommand(t *testing.T) {
+	tests := []struct {
+		name         string
+		command      string
+		wantServer   string
+		wantMethod   string
+		wantBody     string
+		wantMetadata string
+		wantWarnings int
+	}{
+		{
+			name:       "plaintext call",
+			command:    `grpcurl -plaintext -d '{"id":1}' localhost:50051 pkg.Users/Get`,
+			wantServer: "localhost:50051",
+			wantMethod: "pkg.Users/Get",
+			wantBody:   "{\n  \"id\": 1\n}",
+		},
+		{
+			name:       "dotted method",
+			command:    "grpcurl --plaintext=true localhost:50051 pkg.Users.List",
+			wantServer: "localhost:50051",
+			wantMethod: "pkg.Users/List",
+		},
+		{
+			name:         "metadata and TLS",
+			command:      "grpcurl -H 'authorization: Bearer t' api:443 pkg.S/M",
+			wantServer:   "api:443",
+			wantMethod:   "pkg.S/M",
+			wantMetadata: "{\n  \"authorization\": \"Bearer t\"\n}",
+			wantWarnings: 1,
+		},
+		{
+			name:         "proto files are reported",
+			command:      "grpcurl -plaintext -import-path ./protos -proto users.proto localhost:1 pkg.S/M",
+			wantServer:   "localhost:1",
+			wantMethod:   "pkg.S/M",
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, warnings, err := parseGrpcurlCommand(tt.command)
+			if err != nil {
+				t.Fatalf("parseGrpcurlCommand(%q) error: %v", tt.command, err)
+			}
+			if req.Type != "grpc" || req.GrpcServer != tt.wantServer || req.GrpcMethod != tt.wantMethod || req.Body != tt.wantBody {
+				t.Errorf("got %s %s %q, want %s %s %q", req.GrpcServer, req.GrpcMethod, req.Body, tt.wantServer, tt.wantMethod, tt.wantBody)
+			}
+			if req.GrpcMetadata != tt.wantMetadata {
+				t.Errorf("metadata = %q, want %q", req.GrpcMetadata, tt.wantMetadata)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %q, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestParseGrpcurlCommandErrors(t *testing.T) {
+	for _, command := range []string{"curl x", "grpcurl -plaintext localhost:1", "grpcurl -plaintext localhost:1 list", "grpcurl -d"} {
+		if _, _, err := parseGrpcurlCommand(command); err == nil {
+			t.Errorf("parseGrpcurlCommand(%q) succeeded, want an error", command)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collections, environments and the gRPC cache are saved after every change. Each file is
+// written to a temporary file that is then renamed over the old one, so a crash never leaves a
+// half-written file, and the previous version is copied to backups/ the first time a file is
+// replaced in a session. A file that could not be loaded is never overwritten, so its content
+// can still be repaired by hand. /
+// Collections, environments dan cache gRPC disimpan setelah setiap perubahan. Setiap file ditulis
+// ke file sementara yang kemudian di-rename menggantikan file lama, sehingga crash tidak pernah
+// meninggalkan file yang setengah tertulis, dan versi sebelumnya disalin ke backups/ saat file
+// pertama kali diganti dalam satu sesi. File yang gagal dimuat tidak pernah ditimpa, sehingga
+// isinya masih dapat diperbaiki secara manual.
+const (
+	// currentSchemaVersion is the version of the files written by this build. Version 1 is the
+	// unversioned format of earlier releases. /
+	// currentSchemaVersion adalah versi file yang ditulis oleh build ini. Versi 1 adalah format
+	// tanpa versi dari rilis sebelumnya.
+	currentSchemaVersion = 2
+	backupDirName        = "backups"
+	maxBackups           = 10
+)
+
+// schemaFile wraps the content of a versioned file.
+// schemaFile membungkus isi dari file yang memiliki versi.
+type schemaFile struct {
+	SchemaVersion int             `json:"schema_version"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// schemaMigrations upgrade the content of a file of the given kind ("collections",
+// "environments" or "grpc_cache") from the version it is keyed by to the next one. /
+// schemaMigrations meng-upgrade isi file dengan jenis tertentu ("collections", "environments"
+// atau "grpc_cache") dari versi yang menjadi key-nya ke versi berikutnya.
+var schemaMigrations = map[int]func(kind string, data json.RawMessage) (json.RawMessage, error){
+	// Version 2 only added the schemaFile wrapper around the unchanged content.
+	// Versi 2 hanya menambahkan pembungkus schemaFile di sekitar isi yang tidak berubah.
+	1: func(kind string, data json.RawMessage) (json.RawMessage, error) {
+		return data, nil
+	},
+}
+
+// errChangedOnDisk is returned when a watched file was changed outside panggil since it was
+// last loaded or saved, so saving would discard those changes. /
+// errChangedOnDisk dikembalikan jika file yang dipantau diubah di luar panggil sejak terakhir
+// dimuat atau disimpan, sehingga menyimpan akan membuang perubahan tersebut.
+var errChangedOnDisk = errors.New("changed outside panggil")
+
+// persistState tracks the files that failed to load, the files already backed up, and the
+// content of watched files as panggil last loaded or saved them. /
+// persistState mencatat file yang gagal dimuat, file yang sudah di-backup, dan isi file yang
+// dipantau seperti terakhir kali dimuat atau disimpan panggil.
+var persistState = struct {
+	sync.Mutex
+	failed    map[string]error
+	backedUp  map[string]bool
+	synced    map[string]string // Fingerprint of each watched file / Fingerprint dari setiap file yang dipantau
+	conflicts map[string]bool   // Watched files whose save was refused by errChangedOnDisk / File dipantau yang penyimpanannya ditolak oleh errChangedOnDisk
+}{failed: make(map[string]error), backedUp: make(map[string]bool), synced: make(map[string]string), conflicts: make(map[string]bool)}
+
+// markLoadFailed records that path could not be loaded, so it will not be overwritten.
+// markLoadFailed mencatat bahwa path gagal dimuat, sehingga tidak akan ditimpa.
+func markLoadFailed(path string, err error) {
+	persistState.Lock()
+	defer persistState.Unlock()
+	persistState.failed[path] = err
+	log.Printf("ERROR: Could not load %s, it will not be overwritten until it is fixed: %v", path, err)
+}
+
+// markLoaded clears an earlier load failure of path.
+// markLoaded menghapus catatan gagal muat sebelumnya dari path.
+func markLoaded(path string) {
+	persistState.Lock()
+	defer persistState.Unlock()
+	delete(persistState.failed, path)
+}
+
+// loadError returns the error path failed to load with, or nil.
+// loadError mengembalikan error saat path gagal dimuat, atau nil.
+func loadError(path string) error {
+	persistState.Lock()
+	defer persistState.Unlock()
+	return persistState.failed[path]
+}
+
+// loadFailures returns the files that failed to load, sorted by path.
+// loadFailures mengembalikan file yang gagal dimuat, diurutkan berdasarkan path.
+func loadFailures() []string {
+	persistState.Lock()
+	defer persistState.Unlock()
+	var paths []string
+	for path := range persistState.failed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// markSynced records fingerprint as the content of the watched file path as panggil last saw
+// it, and clears a conflict on path. /
+// markSynced mencatat fingerprint sebagai isi file dipantau path seperti terakhir kali dilihat
+// panggil, dan menghapus konflik pada path.
+func markSynced(path, fingerprint string) {
+	persistState.Lock()
+	defer persistState.Unlock()
+	persistState.synced[path] = fingerprint
+	delete(persistState.conflicts, path)
+}
+
+// syncedFingerprint returns the fingerprint recorded by markSynced, and whether path is watched.
+// syncedFingerprint mengembalikan fingerprint yang dicatat markSynced, dan apakah path dipantau.
+func syncedFingerprint(path string) (string, bool) {
+	persistState.Lock()
+	defer persistState.Unlock()
+	fingerprint, ok := persistState.synced[path]
+	return fingerprint, ok
+}
+
+// setConflict records whether a save of path was refused because it changed on disk.
+// setConflict mencatat apakah penyimpanan path ditolak karena berubah di disk.
+func setConflict(path string, conflict bool) {
+	persistState.Lock()
+	defer persistState.Unlock()
+	if conflict {
+		persistState.conflicts[path] = true
+	} else {
+		delete(persistState.conflicts, path)
+	}
+}
+
+// hasConflict reports whether panggil has unsaved changes to path that was changed on disk.
+// hasConflict melaporkan apakah panggil punya perubahan yang belum disimpan pada path yang berubah di disk.
+func hasConflict(path string) bool {
+	persistState.Lock()
+	defer persistState.Unlock()
+	return persistState.conflicts[path]
+}
+
+// readSchemaFile reads a versioned file and migrates its content to the current version.
+// Unversioned files from earlier releases are read as version 1. /
+// readSchemaFile membaca file yang memiliki versi dan memigrasikan isinya ke versi saat ini.
+// File tanpa versi dari rilis sebelumnya dibaca sebagai versi 1.
+func readSchemaFile(path, kind string) (json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var content json.RawMessage
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	var probe map[string]json.RawMessage
+	version := 1
+	if json.Unmarshal(data, &probe) == nil && probe["schema_version"] != nil {
+		var file schemaFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, err
+		}
+		version, content = file.SchemaVersion, file.Data
+	}
+	if version > currentSchemaVersion {
+		return nil, fmt.Errorf("schema version %d is newer than this version of panggil supports (%d)", version, currentSchemaVersion)
+	}
+	for ; version < currentSchemaVersion; version++ {
+		migrate, ok := schemaMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from schema version %d", version)
+		}
+		if content, err = migrate(kind, content); err != nil {
+			return nil, fmt.Errorf("migrating from schema version %d: %w", version, err)
+		}
+		log.Printf("INFO: Migrated %s from schema version %d to %d", path, version, version+1)
+	}
+	return content, nil
+}
+
+// writeSchemaFile saves v as the content of a versioned file with saveFile.
+// writeSchemaFile menyimpan v sebagai isi file yang memiliki versi dengan saveFile.
+func writeSchemaFile(path string, v any, perm os.FileMode) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(schemaFile{SchemaVersion: currentSchemaVersion, Data: content}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return saveFile(path, append(data, '\n'), perm)
+}
+
+// saveFile replaces path with data unless the file failed to load or already has that content.
+// The old content is backed up the first time the file is replaced in this session. /
+// saveFile mengganti path dengan data kecuali file tersebut gagal dimuat atau sudah berisi konten
+// yang sama. Isi lama di-backup saat file pertama kali diganti dalam sesi ini.
+func saveFile(path string, data []byte, perm os.FileMode) error {
+	loadErr := loadError(path)
+	persistState.Lock()
+	backedUp := persistState.backedUp[path]
+	persistState.Unlock()
+	if loadErr != nil {
+		return fmt.Errorf("not overwriting %s because it could not be loaded (%v); fix or move it", path, loadErr)
+	}
+
+	existing, err := os.ReadFile(path)
+	onDisk := ""
+	if err == nil {
+		onDisk = contentFingerprint(existing)
+	}
+	known, watched := syncedFingerprint(path)
+	if watched && onDisk != known {
+		setConflict(path, true)
+		return fmt.Errorf("not overwriting %s: %w", path, errChangedOnDisk)
+	}
+	if watched {
+		defer markSynced(path, contentFingerprint(data))
+	}
+	if err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	if err == nil && !backedUp {
+		if err := backupFile(path, existing); err != nil {
+			log.Printf("WARN: Could not back up %s: %v", path, err)
+		}
+		persistState.Lock()
+		persistState.backedUp[path] = true
+		persistState.Unlock()
+	}
+	return writeFileAtomic(path, data, perm)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path.
+// writeFileAtomic menulis data ke file sementara di sebelah path lalu me-rename-nya menggantikan path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// backupFile stores data as the newest backup of path in the backups directory next to it,
+// keeping the most recent maxBackups backups of each file. The directory ignores itself in git,
+// so the backups of a project workspace are not committed with it. /
+// backupFile menyimpan data sebagai backup terbaru dari path di direktori backups di sebelahnya,
+// dan menyimpan maxBackups backup terakhir dari setiap file. Direktori tersebut mengabaikan
+// dirinya sendiri di git, sehingga backup dari workspace project tidak ikut di-commit.
+func backupFile(path string, data []byte) error {
+	dir := filepath.Join(filepath.Dir(path), backupDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); errors.Is(err, fs.ErrNotExist) {
+		if err := os.WriteFile(ignore, []byte("*\n"), 0644); err != nil {
+			return err
+		}
+	}
+	prefix := filepath.Base(path) + "."
+	name := prefix + time.Now().Format("20060102-150405.000") + ".bak"
+	if err := writeFileAtomic(filepath.Join(dir, name), data, 0600); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), prefix) && strings.HasSuffix(e.Name(), ".bak") {
+			backups = append(backups, e.Name())
+		}
+	}
+	sort.Strings(backups)
+	for len(backups) > maxBackups {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// reportLoadFailures shows the files that failed to load, or else the secrets that could not be
+// decrypted, in the status bar. /
+// reportLoadFailures menampilkan file yang gagal dimuat, atau rahasia yang tidak dapat
+// didekripsi, di status bar.
+func (a *App) reportLoadFailures() {
+	if failed := loadFailures(); len(failed) > 0 {
+		a.statusText.SetText(fmt.Sprintf("[red]Could not load %s; it will not be saved until fixed (see log)", strings.Join(failed, ", ")))
+	} else if n := undecryptableCount(); n > 0 {
+		a.statusText.SetText(fmt.Sprintf("[red]%d secret value(s) could not be decrypted; requests using them will fail (see log)", n))
+	}
+}
@@ -0,0 +1,724 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Postman v2.1 collection structures. Only the fields panggil can represent are decoded.
+// Struktur collection Postman v2.1. Hanya field yang dapat direpresentasikan panggil yang di-decode.
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Auth     *postmanAuth      `json:"auth,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+	Event    []postmanEvent    `json:"event,omitempty"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanItem struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Item        []postmanItem     `json:"item,omitempty"`
+	Request     *postmanRequest   `json:"request,omitempty"`
+	Auth        *postmanAuth      `json:"auth,omitempty"`
+	Event       []postmanEvent    `json:"event,omitempty"`
+	Variable    []postmanVariable `json:"variable,omitempty"`
+	Response    []json.RawMessage `json:"response,omitempty"`
+}
+
+type postmanRequest struct {
+	Method string       `json:"method"`
+	Header []postmanKV  `json:"header,omitempty"`
+	Body   *postmanBody `json:"body,omitempty"`
+	URL    postmanURL   `json:"url"`
+	Auth   *postmanAuth `json:"auth,omitempty"`
+}
+
+// UnmarshalJSON accepts both the object form and the plain URL string form of a request.
+// UnmarshalJSON menerima bentuk object maupun bentuk string URL biasa dari sebuah request.
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		r.Method = "GET"
+		r.URL = postmanURL{Raw: rawURL}
+		return nil
+	}
+	type plain postmanRequest
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+type postmanKV struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Type     string `json:"type,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type postmanBody struct {
+	Mode       string              `json:"mode"`
+	Raw        string              `json:"raw,omitempty"`
+	URLEncoded []postmanKV         `json:"urlencoded,omitempty"`
+	FormData   []postmanKV         `json:"formdata,omitempty"`
+	GraphQL    *postmanGraphQL     `json:"graphql,omitempty"`
+	Options    *postmanBodyOptions `json:"options,omitempty"`
+	Disabled   bool                `json:"disabled,omitempty"`
+}
+
+type postmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+type postmanGraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables"`
+}
+
+type postmanURL struct {
+	Raw      string      `json:"raw"`
+	Protocol string      `json:"protocol,omitempty"`
+	Host     []string    `json:"host,omitempty"`
+	Port     string      `json:"port,omitempty"`
+	Path     []string    `json:"path,omitempty"`
+	Query    []postmanKV `json:"query,omitempty"`
+	Variable []postmanKV `json:"variable,omitempty"`
+}
+
+// UnmarshalJSON accepts both the object form and the plain string form of a URL.
+// UnmarshalJSON menerima bentuk object maupun bentuk string biasa dari sebuah URL.
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+		return nil
+	}
+	type plain postmanURL
+	return json.Unmarshal(data, (*plain)(u))
+}
+
+type postmanAuth struct {
+	Type   string      `json:"type"`
+	Bearer []postmanKV `json:"bearer,omitempty"`
+	Basic  []postmanKV `json:"basic,omitempty"`
+	APIKey []postmanKV `json:"apikey,omitempty"`
+}
+
+type postmanEvent struct {
+	Listen string `json:"listen"`
+}
+
+type postmanVariable struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Disabled bool        `json:"disabled,omitempty"`
+}
+
+// postmanEnvironment is a Postman environment export file.
+// postmanEnvironment adalah file export environment Postman.
+type postmanEnvironment struct {
+	Name   string `json:"name"`
+	Values []struct {
+		Key     string      `json:"key"`
+		Value   interface{} `json:"value"`
+		Enabled *bool       `json:"enabled,omitempty"`
+	} `json:"values"`
+	Scope string `json:"_postman_variable_scope,omitempty"`
+}
+
+// isPostmanCollection reports whether data looks like a Postman collection export.
+// isPostmanCollection melaporkan apakah data terlihat seperti export collection Postman.
+func isPostmanCollection(data []byte) bool {
+	var probe struct {
+		Info struct {
+			Schema    string `json:"schema"`
+			PostmanID string `json:"_postman_id"`
+		} `json:"info"`
+		Item json.RawMessage `json:"item"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return strings.Contains(probe.Info.Schema, "getpostman.com") ||
+		(probe.Info.PostmanID != "" && probe.Item != nil)
+}
+
+// isPostmanEnvironment reports whether data looks like a Postman environment export.
+// isPostmanEnvironment melaporkan apakah data terlihat seperti export environment Postman.
+func isPostmanEnvironment(data []byte) bool {
+	var probe struct {
+		Scope  string          `json:"_postman_variable_scope"`
+		Name   string          `json:"name"`
+		Values json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.Scope == "environment" || probe.Scope == "globals" || (probe.Name != "" && probe.Values != nil)
+}
+
+// postmanImporter accumulates warnings about Postman features that were skipped.
+// postmanImporter mengumpulkan peringatan tentang fitur Postman yang dilewati.
+type postmanImporter struct {
+	warnings []string
+}
+
+// warnf records a warning about a skipped Postman feature.
+// warnf mencatat peringatan tentang fitur Postman yang dilewati.
+func (p *postmanImporter) warnf(format string, args ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+}
+
+// importPostmanCollection converts a Postman v2.1 collection into a folder of the collections
+// tree. Collection-level variables are returned as an environment named after the collection. /
+// importPostmanCollection mengkonversi collection Postman v2.1 menjadi sebuah folder di tree
+// Collections. Variabel level collection dikembalikan sebagai environment dengan nama collection.
+func importPostmanCollection(data []byte) (*ImportResult, error) {
+	var pc postmanCollection
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return nil, fmt.Errorf("parsing Postman collection: %w", err)
+	}
+	if pc.Info.Schema != "" && !strings.Contains(pc.Info.Schema, "v2.1") {
+		return nil, fmt.Errorf("unsupported Postman collection schema %q (export as v2.1)", pc.Info.Schema)
+	}
+
+	name := pc.Info.Name
+	if name == "" {
+		name = "Postman Import"
+	}
+
+	p := &postmanImporter{}
+	p.checkEvents(name, pc.Event)
+	root := &CollectionNode{Name: name, IsFolder: true}
+	root.Children = p.convertItems(pc.Item, pc.Auth, name)
+
+	result := &ImportResult{Collection: root}
+	if vars := postmanVariablesToMap(pc.Variable); len(vars) > 0 {
+		result.Environments = append(result.Environments, &Environment{Name: name, Variables: vars})
+	}
+	result.Warnings = p.warnings
+	return result, nil
+}
+
+// convertItems converts Postman items recursively, passing the inherited auth down to requests.
+// convertItems mengkonversi item Postman secara rekursif, meneruskan auth turunan ke request.
+func (p *postmanImporter) convertItems(items []postmanItem, inherited *postmanAuth, path string) []*CollectionNode {
+	var nodes []*CollectionNode
+	for _, item := range items {
+		itemPath := path + "/" + item.Name
+		p.checkEvents(itemPath, item.Event)
+		if len(item.Variable) > 0 {
+			p.warnf("%s: item variables are not supported", itemPath)
+		}
+
+		if item.Request == nil {
+			auth := inherited
+			if item.Auth != nil && item.Auth.Type != "inherit" {
+				auth = item.Auth
+			}
+			nodes = append(nodes, &CollectionNode{
+				Name:     item.Name,
+				IsFolder: true,
+				Children: p.convertItems(item.Item, auth, itemPath),
+			})
+			continue
+		}
+
+		if len(item.Response) > 0 {
+			p.warnf("%s: %d saved example response(s) skipped", itemPath, len(item.Response))
+		}
+		if strings.HasPrefix(item.Request.URL.Raw, grpcURLScheme) {
+			nodes = append(nodes, &CollectionNode{
+				Name:    item.Name,
+				Request: p.convertGrpcRequest(item.Name, item.Request),
+			})
+			continue
+		}
+		nodes = append(nodes, &CollectionNode{
+			Name:    item.Name,
+			Request: p.convertRequest(item.Name, item.Request, inherited, itemPath),
+		})
+	}
+	return nodes
+}
+
+// checkEvents reports Postman scripts, which use the pm.* API and cannot be run as-is.
+// checkEvents melaporkan script Postman, yang memakai API pm.* dan tidak dapat dijalankan apa adanya.
+func (p *postmanImporter) checkEvents(path string, events []postmanEvent) {
+	for _, ev := range events {
+		p.warnf("%s: %s script skipped (Postman pm.* scripts are not supported)", path, ev.Listen)
+	}
+}
+
+// convertRequest maps a Postman request onto a panggil HTTP request.
+// convertRequest memetakan request Postman ke request HTTP panggil.
+func (p *postmanImporter) convertRequest(name string, pr *postmanRequest, inherited *postmanAuth, path string) *Request {
+	req := &Request{
+		Name:    name,
+		Type:    "http",
+		Method:  strings.ToUpper(pr.Method),
+		Headers: make(map[string]string),
+		Time:    time.Now(),
+	}
+	if req.Method == "" {
+		req.Method = "GET"
+	}
+
+	for _, h := range pr.Header {
+		if h.Disabled || h.Key == "" {
+			continue
+		}
+		req.Headers[h.Key] = h.Value
+	}
+
+	req.URL = p.convertURL(pr.URL, path)
+
+	if pr.Body != nil && !pr.Body.Disabled {
+		p.convertBody(req, pr.Body, path)
+	}
+
+	auth := inherited
+	if pr.Auth != nil && pr.Auth.Type != "inherit" {
+		auth = pr.Auth
+	}
+	p.convertAuth(req, auth, path)
+
+	if strings.Contains(req.URL+req.Body, "{{$") || headersContain(req.Headers, "{{$") {
+		p.warnf("%s: Postman dynamic variables such as {{$guid}} are not resolved", path)
+	}
+
+	if len(req.Headers) > 0 {
+		raw, _ := json.MarshalIndent(req.Headers, "", "  ")
+		req.HeadersRaw = string(raw)
+	}
+	return req
+}
+
+// convertURL builds the request URL, substituting `:name` path variables with their values.
+// convertURL menyusun URL request, mengganti path variable `:name` dengan nilainya.
+func (p *postmanImporter) convertURL(u postmanURL, path string) string {
+	raw := u.Raw
+	if raw == "" {
+		raw = strings.Join(u.Host, ".")
+		if u.Protocol != "" {
+			raw = u.Protocol + "://" + raw
+		}
+		if u.Port != "" {
+			raw += ":" + u.Port
+		}
+		if len(u.Path) > 0 {
+			raw += "/" + strings.Join(u.Path, "/")
+		}
+		var query []string
+		for _, q := range u.Query {
+			if q.Disabled {
+				continue
+			}
+			query = append(query, url.QueryEscape(q.Key)+"="+q.Value)
+		}
+		if len(query) > 0 {
+			raw += "?" + strings.Join(query, "&")
+		}
+	}
+
+	for _, v := range u.Variable {
+		if v.Key == "" {
+			continue
+		}
+		if v.Value == "" {
+			p.warnf("%s: path variable :%s has no value", path, v.Key)
+			continue
+		}
+		raw = replacePathVariable(raw, v.Key, v.Value)
+	}
+	return raw
+}
+
+// replacePathVariable replaces the `:key` path segment of a URL with value.
+// replacePathVariable mengganti segmen path `:key` dari sebuah URL dengan value.
+func replacePathVariable(rawURL, key, value string) string {
+	segments := strings.Split(rawURL, "/")
+	for i, seg := range segments {
+		base, rest, _ := strings.Cut(seg, "?")
+		if base == ":"+key {
+			segments[i] = value
+			if rest != "" {
+				segments[i] += "?" + rest
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// convertBody maps the Postman body modes onto a text body and the matching Content-Type.
+// convertBody memetakan mode body Postman ke body teks dan Content-Type yang sesuai.
+func (p *postmanImporter) convertBody(req *Request, body *postmanBody, path string) {
+	switch body.Mode {
+	case "raw":
+		req.Body = body.Raw
+		language := ""
+		if body.Options != nil {
+			language = body.Options.Raw.Language
+		}
+		contentType := map[string]string{
+			"json":       "application/json",
+			"xml":        "application/xml",
+			"html":       "text/html",
+			"text":       "text/plain",
+			"javascript": "application/javascript",
+		}[language]
+		if contentType != "" && !headersHave(req.Headers, "Content-Type") {
+			req.Headers["Content-Type"] = contentType
+		}
+	case "urlencoded":
+		var parts []string
+		for _, kv := range body.URLEncoded {
+			if kv.Disabled {
+				continue
+			}
+			parts = append(parts, url.QueryEscape(kv.Key)+"="+url.QueryEscape(kv.Value))
+		}
+		req.Body = strings.Join(parts, "&")
+		if !headersHave(req.Headers, "Content-Type") {
+			req.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+		}
+	case "graphql":
+		if body.GraphQL == nil {
+			return
+		}
+		payload := map[string]interface{}{"query": body.GraphQL.Query}
+		if strings.TrimSpace(body.GraphQL.Variables) != "" {
+			payload["variables"] = json.RawMessage(body.GraphQL.Variables)
+		}
+		encoded, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			p.warnf("%s: GraphQL variables are not valid JSON, body skipped", path)
+			return
+		}
+		req.Body = string(encoded)
+		if !headersHave(req.Headers, "Content-Type") {
+			req.Headers["Content-Type"] = "application/json"
+		}
+	case "formdata":
+		p.warnf("%s: multipart form-data body skipped (%d field(s))", path, len(body.FormData))
+	case "file":
+		p.warnf("%s: binary file body skipped", path)
+	case "":
+	default:
+		p.warnf("%s: body mode '%s' skipped", path, body.Mode)
+	}
+}
+
+// convertAuth maps bearer and basic auth to the auth panel. API keys become a header or query
+// parameter, since the API Key auth type only stores the key value. /
+// convertAuth memetakan auth bearer dan basic ke panel auth. API key menjadi header atau query
+// parameter, karena auth type API Key hanya menyimpan nilai key.
+func (p *postmanImporter) convertAuth(req *Request, auth *postmanAuth, path string) {
+	if auth == nil {
+		return
+	}
+	values := func(kvs []postmanKV) map[string]string {
+		m := make(map[string]string)
+		for _, kv := range kvs {
+			m[kv.Key] = kv.Value
+		}
+		return m
+	}
+
+	switch auth.Type {
+	case "noauth", "inherit", "":
+	case "bearer":
+		req.AuthType = getAuthTypeIndex("Bearer Token")
+		req.AuthToken = values(auth.Bearer)["token"]
+	case "basic":
+		v := values(auth.Basic)
+		req.AuthType = getAuthTypeIndex("Basic Auth")
+		req.AuthUser = v["username"]
+		req.AuthPass = v["password"]
+	case "apikey":
+		v := values(auth.APIKey)
+		key := v["key"]
+		if key == "" {
+			key = "X-API-Key"
+		}
+		if v["in"] == "query" {
+			sep := "?"
+			if strings.Contains(req.URL, "?") {
+				sep = "&"
+			}
+			req.URL += sep + url.QueryEscape(key) + "=" + v["value"]
+		} else {
+			req.Headers[key] = v["value"]
+		}
+	default:
+		p.warnf("%s: auth type '%s' is not supported", path, auth.Type)
+	}
+}
+
+// importPostmanEnvironment converts a Postman environment (or globals) export into an Environment.
+// importPostmanEnvironment mengkonversi export environment (atau globals) Postman menjadi Environment.
+func importPostmanEnvironment(data []byte) (*ImportResult, error) {
+	var pe postmanEnvironment
+	if err := json.Unmarshal(data, &pe); err != nil {
+		return nil, fmt.Errorf("parsing Postman environment: %w", err)
+	}
+	name := pe.Name
+	if name == "" {
+		name = "Postman Environment"
+	}
+
+	env := &Environment{Name: name, Variables: make(map[string]string)}
+	var warnings []string
+	for _, v := range pe.Values {
+		if v.Key == "" {
+			continue
+		}
+		if v.Enabled != nil && !*v.Enabled {
+			warnings = append(warnings, fmt.Sprintf("%s: disabled variable '%s' skipped", name, v.Key))
+			continue
+		}
+		env.Variables[v.Key] = valueToString(v.Value)
+	}
+	return &ImportResult{Environments: []*Environment{env}, Warnings: warnings}, nil
+}
+
+// postmanVariablesToMap converts collection variables into a variable map.
+// postmanVariablesToMap mengkonversi variabel collection menjadi map variabel.
+func postmanVariablesToMap(vars []postmanVariable) map[string]string {
+	m := make(map[string]string)
+	for _, v := range vars {
+		if v.Key == "" || v.Disabled {
+			continue
+		}
+		m[v.Key] = valueToString(v.Value)
+	}
+	return m
+}
+
+// valueToString converts a decoded value, which may be any JSON type, to text.
+// valueToString mengkonversi nilai yang sudah di-decode, yang bisa berupa tipe JSON apa pun, menjadi teks.
+func valueToString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
+
+// headersHave reports whether headers contain name, ignoring case.
+// headersHave melaporkan apakah headers berisi name, tanpa memperhatikan huruf besar/kecil.
+func headersHave(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// headersContain reports whether any header value contains substr.
+// headersContain melaporkan apakah ada nilai header yang mengandung substr.
+func headersContain(headers map[string]string, substr string) bool {
+	for _, v := range headers {
+		if strings.Contains(v, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// grpcURLScheme prefixes the URL of exported gRPC requests, e.g. grpc://localhost:8081/pkg.Service/Method.
+// Postman v2.1 has no request type for gRPC, so such items are exported as POST requests with
+// this URL and are converted back to gRPC requests on import. /
+// grpcURLScheme menjadi awalan URL dari request gRPC yang di-export, mis. grpc://localhost:8081/pkg.Service/Method.
+// Postman v2.1 tidak memiliki tipe request untuk gRPC, sehingga item tersebut di-export sebagai request POST
+// dengan URL ini dan dikonversi kembali menjadi request gRPC saat di-import.
+const grpcURLScheme = "grpc://"
+
+// convertGrpcRequest converts an item exported by exportPostmanCollection back into a gRPC request.
+// convertGrpcRequest mengkonversi item yang di-export oleh exportPostmanCollection kembali menjadi request gRPC.
+func (p *postmanImporter) convertGrpcRequest(name string, pr *postmanRequest) *Request {
+	target := strings.TrimPrefix(pr.URL.Raw, grpcURLScheme)
+	server, method, _ := strings.Cut(target, "/")
+
+	req := &Request{
+		Name:       name,
+		Type:       "grpc",
+		GrpcServer: server,
+		GrpcMethod: method,
+		Time:       time.Now(),
+	}
+	meta := make(map[string]string)
+	for _, h := range pr.Header {
+		if !h.Disabled && h.Key != "" {
+			meta[h.Key] = h.Value
+		}
+	}
+	if len(meta) > 0 {
+		encoded, _ := json.MarshalIndent(meta, "", "  ")
+		req.GrpcMetadata = string(encoded)
+	}
+	if pr.Body != nil {
+		req.Body = pr.Body.Raw
+	}
+	return req
+}
+
+// exportPostmanCollection converts a collection folder (or a single request) into a Postman
+// v2.1 collection. Scripts, captures and assertions have no Postman equivalent and are left out. /
+// exportPostmanCollection mengkonversi sebuah folder collection (atau satu request) menjadi collection
+// Postman v2.1. Script, capture dan assertion tidak memiliki padanan di Postman dan tidak disertakan.
+func exportPostmanCollection(node *CollectionNode) ([]byte, error) {
+	pc := postmanCollection{
+		Info: postmanInfo{
+			Name:   node.Name,
+			Schema: "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+		Item: []postmanItem{},
+	}
+	if node.IsFolder {
+		pc.Item = append(pc.Item, exportPostmanItems(node.Children)...)
+	} else {
+		pc.Item = append(pc.Item, exportPostmanItems([]*CollectionNode{node})...)
+	}
+
+	data, err := json.MarshalIndent(pc, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling Postman collection: %w", err)
+	}
+	return data, nil
+}
+
+// exportPostmanItems converts collection nodes into Postman items recursively.
+// exportPostmanItems mengkonversi node collection menjadi item Postman secara rekursif.
+func exportPostmanItems(nodes []*CollectionNode) []postmanItem {
+	items := make([]postmanItem, 0, len(nodes))
+	for _, node := range nodes {
+		if node.IsFolder {
+			items = append(items, postmanItem{Name: node.Name, Item: exportPostmanItems(node.Children)})
+			continue
+		}
+		if node.Request == nil {
+			continue
+		}
+		if node.Request.Type == "grpc" {
+			items = append(items, exportPostmanGrpcItem(node.Name, node.Request))
+			continue
+		}
+		items = append(items, postmanItem{Name: node.Name, Request: exportPostmanRequest(node.Request)})
+	}
+	return items
+}
+
+// exportPostmanRequest converts an HTTP request, including its auth settings, into a Postman request.
+// exportPostmanRequest mengkonversi request HTTP, termasuk pengaturan auth-nya, menjadi request Postman.
+func exportPostmanRequest(req *Request) *postmanRequest {
+	method := req.Method
+	if method == "" {
+		method = "GET"
+	}
+	pr := &postmanRequest{
+		Method: method,
+		Header: sortedPostmanKVs(requestHeaders(req)),
+		URL:    exportPostmanURL(req.URL),
+	}
+
+	if req.Body != "" {
+		pr.Body = &postmanBody{Mode: "raw", Raw: req.Body}
+		if json.Valid([]byte(req.Body)) || strings.HasPrefix(strings.TrimSpace(req.Body), "{") {
+			pr.Body.Options = &postmanBodyOptions{}
+			pr.Body.Options.Raw.Language = "json"
+		}
+	}
+
+	switch getAuthTypeName(req.AuthType) {
+	case "Bearer Token":
+		pr.Auth = &postmanAuth{Type: "bearer", Bearer: []postmanKV{
+			{Key: "token", Value: req.AuthToken, Type: "string"},
+		}}
+	case "Basic Auth":
+		pr.Auth = &postmanAuth{Type: "basic", Basic: []postmanKV{
+			{Key: "username", Value: req.AuthUser, Type: "string"},
+			{Key: "password", Value: req.AuthPass, Type: "string"},
+		}}
+	case "API Key":
+		// panggil stores only the key value; the header name is left for the user to fill in.
+		// panggil hanya menyimpan nilai key; nama header dibiarkan untuk diisi oleh user.
+		pr.Auth = &postmanAuth{Type: "apikey", APIKey: []postmanKV{
+			{Key: "value", Value: req.AuthToken, Type: "string"},
+			{Key: "in", Value: "header", Type: "string"},
+		}}
+	default:
+		pr.Auth = &postmanAuth{Type: "noauth"}
+	}
+	return pr
+}
+
+// exportPostmanGrpcItem represents a gRPC request as a POST request to a grpc:// URL with the
+// metadata as headers and the JSON message as a raw body. /
+// exportPostmanGrpcItem merepresentasikan request gRPC sebagai request POST ke URL grpc:// dengan
+// metadata sebagai header dan pesan JSON sebagai body raw.
+func exportPostmanGrpcItem(name string, req *Request) postmanItem {
+	meta, err := parseGrpcMetadata(req.GrpcMetadata)
+	if err != nil {
+		meta = nil
+	}
+	pr := &postmanRequest{
+		Method: "POST",
+		Header: sortedPostmanKVs(meta),
+		URL:    postmanURL{Raw: grpcURLScheme + req.GrpcServer + "/" + req.GrpcMethod},
+		Body:   &postmanBody{Mode: "raw", Raw: req.Body, Options: &postmanBodyOptions{}},
+	}
+	pr.Body.Options.Raw.Language = "json"
+	return postmanItem{
+		Name:        name,
+		Description: fmt.Sprintf("gRPC request: %s on %s (exported from panggil)", req.GrpcMethod, req.GrpcServer),
+		Request:     pr,
+	}
+}
+
+// exportPostmanURL splits the query string of a URL into Postman query parameters.
+// exportPostmanURL memecah query string dari sebuah URL menjadi query parameter Postman.
+func exportPostmanURL(rawURL string) postmanURL {
+	u := postmanURL{Raw: rawURL}
+	_, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return u
+	}
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		u.Query = append(u.Query, postmanKV{Key: key, Value: value})
+	}
+	return u
+}
+
+// sortedPostmanKVs converts a map into key/value pairs sorted by key for stable output.
+// sortedPostmanKVs mengkonversi map menjadi pasangan key/value yang diurutkan berdasarkan key agar output stabil.
+func sortedPostmanKVs(m map[string]string) []postmanKV {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]postmanKV, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, postmanKV{Key: k, Value: m[k]})
+	}
+	return kvs
+}
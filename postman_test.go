@@ -0,0 +1,207 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportPostmanCollection(t *testing.T) {
+	data := []byte(`{
+		"info": {"name": "Shop", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+		"variable": [
+			{"key": "baseUrl", "value": "https://shop.test"},
+			{"key": "retries", "value": 3},
+			{"key": "off", "value": "x", "disabled": true}
+		],
+		"item": [
+			{
+				"name": "Users",
+				"item": [
+					{
+						"name": "Get user",
+						"request": {
+							"method": "get",
+							"url": {"raw": "{{baseUrl}}/users/:id", "variable": [{"key": "id", "value": "42"}]}
+						}
+					},
+					{
+						"name": "Create user",
+						"event": [{"listen": "test"}],
+						"request": {
+							"method": "POST",
+							"header": [{"key": "X-Off", "value": "1", "disabled": true}],
+							"body": {"mode": "raw", "raw": "{\"name\":\"a\"}", "options": {"raw": {"language": "json"}}},
+							"auth": {"type": "basic", "basic": [{"key": "username", "value": "u"}, {"key": "password", "value": "p"}]}
+						}
+					}
+				]
+			},
+			{
+				"name": "Login",
+				"request": {
+					"method": "POST",
+					"url": "{{baseUrl}}/login",
+					"body": {"mode": "urlencoded", "urlencoded": [{"key": "user", "value": "a b"}, {"key": "skip", "value": "1", "disabled": true}]},
+					"auth": {"type": "apikey", "apikey": [{"key": "key", "value": "api_key"}, {"key": "value", "value": "k"}, {"key": "in", "value": "query"}]}
+				}
+			},
+			{
+				"name": "Ping",
+				"request": {"method": "POST", "url": "grpc://localhost:50051/pkg.Health/Check"}
+			}
+		]
+	}`)
+
+	result, err := importPostmanCollection(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := result.Collection
+	if root.Name != "Shop" || !root.IsFolder || len(root.Children) != 3 {
+		t.Fatalf("unexpected root %q with %d children", root.Name, len(root.Children))
+	}
+
+	wantVars := map[string]string{"baseUrl": "https://shop.test", "retries": "3"}
+	if len(result.Environments) != 1 || result.Environments[0].Name != "Shop" || !reflect.DeepEqual(result.Environments[0].Variables, wantVars) {
+		t.Errorf("environments = %+v, want Shop with %v", result.Environments, wantVars)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the test script", result.Warnings)
+	}
+
+	users := root.Children[0]
+	if !users.IsFolder || len(users.Children) != 2 {
+		t.Fatalf("Users folder has %d children", len(users.Children))
+	}
+
+	tests := []struct {
+		name        string
+		req         *Request
+		wantMethod  string
+		wantURL     string
+		wantBody    string
+		wantHeaders map[string]string
+		wantAuth    int
+		wantToken   string
+		wantUser    string
+	}{
+		{
+			name:        "path variable and inherited bearer",
+			req:         users.Children[0].Request,
+			wantMethod:  "GET",
+			wantURL:     "{{baseUrl}}/users/42",
+			wantHeaders: map[string]string{},
+			wantAuth:    getAuthTypeIndex("Bearer Token"),
+			wantToken:   "{{token}}",
+		},
+		{
+			name:        "raw JSON body and own basic auth",
+			req:         users.Children[1].Request,
+			wantMethod:  "POST",
+			wantBody:    `{"name":"a"}`,
+			wantHeaders: map[string]string{"Content-Type": "application/json"},
+			wantAuth:    getAuthTypeIndex("Basic Auth"),
+			wantUser:    "u",
+		},
+		{
+			name:        "urlencoded body and API key in query",
+			req:         root.Children[1].Request,
+			wantMethod:  "POST",
+			wantURL:     "{{baseUrl}}/login?api_key=k",
+			wantBody:    "user=a+b",
+			wantHeaders: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			if req.Type != "http" || req.Method != tt.wantMethod || req.URL != tt.wantURL || req.Body != tt.wantBody {
+				t.Errorf("got %s %s %q, want %s %s %q", req.Method, req.URL, req.Body, tt.wantMethod, tt.wantURL, tt.wantBody)
+			}
+			if !reflect.DeepEqual(req.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", req.Headers, tt.wantHeaders)
+			}
+			if req.AuthType != tt.wantAuth || req.AuthToken != tt.wantToken || req.AuthUser != tt.wantUser {
+				t.Errorf("auth = %d %q %q, want %d %q %q", req.AuthType, req.AuthToken, req.AuthUser, tt.wantAuth, tt.wantToken, tt.wantUser)
+			}
+		})
+	}
+
+	ping := root.Children[2].Request
+	if ping.Type != "grpc" || ping.GrpcServer != "localhost:50051" || ping.GrpcMethod != "pkg.Health/Check" {
+		t.Errorf("gRPC item = %s %s %s", ping.Type, ping.GrpcServer, ping.GrpcMethod)
+	}
+}
+
+func TestImportPostmanCollectionSchema(t *testing.T) {
+	data := []byte(`{"info": {"name": "Old", "schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"}, "item": []}`)
+	if _, err := importPostmanCollection(data); err == nil {
+		t.Error("importing a v2.0 collection succeeded, want an error")
+	}
+}
+
+func TestImportPostmanEnvironment(t *testing.T) {
+	data := []byte(`{
+		"name": "Staging",
+		"_postman_variable_scope": "environment",
+		"values": [
+			{"key": "host", "value": "staging.test", "enabled": true},
+			{"key": "port", "value": 8080},
+			{"key": "old", "value": "x", "enabled": false}
+		]
+	}`)
+	result, err := importPostmanEnvironment(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"host": "staging.test", "port": "8080"}
+	if len(result.Environments) != 1 || result.Environments[0].Name != "Staging" || !reflect.DeepEqual(result.Environments[0].Variables, want) {
+		t.Errorf("environments = %+v, want Staging with %v", result.Environments, want)
+	}
+	if len(result.Warnings) != 1 {
+		t.Errorf("warnings = %q, want one for the disabled variable", result.Warnings)
+	}
+}
+
+func TestPostmanDetection(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		wantCollection bool
+		wantEnv        bool
+	}{
+		{name: "collection schema", data: `{"info": {"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}, "item": []}`, wantCollection: true},
+		{name: "collection id", data: `{"info": {"_postman_id": "abc"}, "item": []}`, wantCollection: true},
+		{name: "environment", data: `{"name": "e", "_postman_variable_scope": "environment", "values": []}`, wantEnv: true},
+		{name: "globals", data: `{"_postman_variable_scope": "globals"}`, wantEnv: true},
+		{name: "openapi", data: `{"openapi": "3.0.0", "paths": {}}`},
+		{name: "not json", data: `curl x`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPostmanCollection([]byte(tt.data)); got != tt.wantCollection {
+				t.Errorf("isPostmanCollection = %v, want %v", got, tt.wantCollection)
+			}
+			if got := isPostmanEnvironment([]byte(tt.data)); got != tt.wantEnv {
+				t.Errorf("isPostmanEnvironment = %v, want %v", got, tt.wantEnv)
+			}
+		})
+	}
+}
+
+func TestReplacePathVariable(t *testing.T) {
+	tests := []struct {
+		url, key, value, want string
+	}{
+		{"https://x/users/:id", "id", "7", "https://x/users/7"},
+		{"https://x/users/:id?full=1", "id", "7", "https://x/users/7?full=1"},
+		{"https://x/users/:idx", "id", "7", "https://x/users/:idx"},
+		{"https://x:8080/a", "8080", "1", "https://x:8080/a"},
+	}
+	for _, tt := range tests {
+		if got := replacePathVariable(tt.url, tt.key, tt.value); got != tt.want {
+			t.Errorf("replacePathVariable(%q, %q) = %q, want %q", tt.url, tt.key, got, tt.want)
+		}
+	}
+}
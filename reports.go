@@ -0,0 +1,330 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// RunReportInfo describes the collection run a report was produced from.
+// RunReportInfo menjelaskan collection run yang menjadi sumber sebuah laporan.
+type RunReportInfo struct {
+	Collection  string
+	Environment string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	// Masks secret values and command output in the written reports, see secretRedactor /
+	// Menyamarkan nilai rahasia dan output perintah di laporan yang ditulis, lihat secretRedactor
+	Redactor *strings.Replacer
+}
+
+// redact masks the secret values in s.
+// redact menyamarkan nilai rahasia di s.
+func (info RunReportInfo) redact(s string) string {
+	if info.Redactor == nil {
+		return s
+	}
+	return info.Redactor.Replace(s)
+}
+
+// redactMap returns a copy of m with the secret values in its values masked.
+// redactMap mengembalikan salinan m dengan nilai rahasia di value-nya disamarkan.
+func (info RunReportInfo) redactMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	redacted := make(map[string]string, len(m))
+	for k, v := range m {
+		redacted[k] = info.redact(v)
+	}
+	return redacted
+}
+
+// JUnit XML structures / Struktur JUnit XML
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     float64          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      float64         `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the run results as JUnit XML with one test suite per iteration.
+// Each assertion becomes a test case; requests without assertions become a single test case. /
+// writeJUnitReport menulis hasil run sebagai JUnit XML dengan satu test suite per iterasi.
+// Setiap assertion menjadi satu test case; request tanpa assertion menjadi satu test case.
+func writeJUnitReport(path string, info RunReportInfo, results []RunResult) error {
+	report := junitTestSuites{Name: info.Collection}
+
+	for _, it := range summarizeIterations(results) {
+		suite := junitTestSuite{
+			Name:      fmt.Sprintf("%s (iteration %d)", info.Collection, it.Iteration),
+			Time:      it.Duration.Seconds(),
+			Timestamp: info.StartedAt.Format("2006-01-02T15:04:05"),
+		}
+		for _, r := range results {
+			if r.Iteration == it.Iteration {
+				suite.Cases = append(suite.Cases, junitCasesFor(r, info)...)
+			}
+		}
+		for _, c := range suite.Cases {
+			suite.Tests++
+			if c.Failure != nil {
+				suite.Failures++
+			}
+			if c.Error != nil {
+				suite.Errors++
+			}
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Time += suite.Time
+		report.Suites = append(report.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing JUnit report: %w", err)
+	}
+	return nil
+}
+
+// junitCasesFor converts a single run result into JUnit test cases, masking secret values in
+// the messages with info's redactor. /
+// junitCasesFor mengkonversi satu hasil run menjadi test case JUnit, dengan menyamarkan nilai
+// rahasia di pesannya memakai redactor dari info.
+func junitCasesFor(r RunResult, info RunReportInfo) []junitTestCase {
+	seconds := r.Duration.Seconds()
+	if r.Error != nil {
+		message := info.redact(r.Error.Error())
+		return []junitTestCase{{
+			Name:      r.Path,
+			ClassName: r.Path,
+			Time:      seconds,
+			Error:     &junitMessage{Message: message, Type: "RequestError", Text: message},
+		}}
+	}
+
+	var cases []junitTestCase
+	if r.ScriptError != nil {
+		message := info.redact(r.ScriptError.Error())
+		cases = append(cases, junitTestCase{
+			Name:      r.Path + " (post-response script)",
+			ClassName: r.Path,
+			Time:      seconds,
+			Failure:   &junitMessage{Message: message, Type: "ScriptFailure", Text: message},
+		})
+	}
+
+	if len(r.Assertions) == 0 {
+		c := junitTestCase{Name: r.Path, ClassName: r.Path, Time: seconds}
+		if r.StatusCode >= 400 {
+			reason := fmt.Sprintf("unexpected status %s", r.Status)
+			c.Failure = &junitMessage{Message: reason, Type: "StatusFailure", Text: reason}
+		}
+		return append(cases, c)
+	}
+
+	for _, as := range r.Assertions {
+		c := junitTestCase{Name: info.redact(as.Assertion.String()), ClassName: r.Path, Time: seconds}
+		if !as.Passed {
+			message := info.redact(as.Message)
+			c.Failure = &junitMessage{Message: message, Type: "AssertionFailure", Text: message}
+		}
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+// JSON report structures / Struktur laporan JSON
+type jsonReport struct {
+	Collection  string             `json:"collection"`
+	Environment string             `json:"environment"`
+	StartedAt   time.Time          `json:"started_at"`
+	FinishedAt  time.Time          `json:"finished_at"`
+	DurationMs  int64              `json:"duration_ms"`
+	Total       int                `json:"total"`
+	Passed      int                `json:"passed"`
+	Failed      int                `json:"failed"`
+	Results     []jsonReportResult `json:"results"`
+}
+
+type jsonReportResult struct {
+	Iteration  int                   `json:"iteration"`
+	Data       map[string]string     `json:"data,omitempty"`
+	Path       string                `json:"path"`
+	Type       string                `json:"type"`
+	Passed     bool                  `json:"passed"`
+	Error      string                `json:"error,omitempty"`
+	ScriptErr  string                `json:"script_error,omitempty"`
+	Logs       []string              `json:"console,omitempty"`
+	DurationMs float64               `json:"duration_ms"`
+	Request    jsonReportRequest     `json:"request"`
+	Response   *jsonReportResponse   `json:"response,omitempty"`
+	Assertions []jsonReportAssertion `json:"assertions,omitempty"`
+}
+
+type jsonReportRequest struct {
+	Method   string            `json:"method,omitempty"`
+	URL      string            `json:"url,omitempty"`
+	Server   string            `json:"server,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	AuthType string            `json:"auth_type,omitempty"`
+	Body     string            `json:"body,omitempty"`
+}
+
+type jsonReportResponse struct {
+	Status     string      `json:"status"`
+	StatusCode int         `json:"status_code,omitempty"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body"`
+}
+
+type jsonReportAssertion struct {
+	Assertion string `json:"assertion"`
+	Passed    bool   `json:"passed"`
+	Actual    string `json:"actual"`
+	Message   string `json:"message,omitempty"`
+}
+
+// writeJSONReport writes the run results with full request/response details and timings.
+// Secret values and command output are masked with info's redactor. /
+// writeJSONReport menulis hasil run dengan detail request/response lengkap beserta waktunya.
+// Nilai rahasia dan output perintah disamarkan dengan redactor dari info.
+func writeJSONReport(path string, info RunReportInfo, results []RunResult) error {
+	passed, failed, _ := summarizeRun(results)
+	report := jsonReport{
+		Collection:  info.Collection,
+		Environment: info.Environment,
+		StartedAt:   info.StartedAt,
+		FinishedAt:  info.FinishedAt,
+		DurationMs:  info.FinishedAt.Sub(info.StartedAt).Milliseconds(),
+		Total:       len(results),
+		Passed:      passed,
+		Failed:      failed,
+		Results:     make([]jsonReportResult, 0, len(results)),
+	}
+
+	for _, r := range results {
+		entry := jsonReportResult{
+			Iteration:  r.Iteration,
+			Data:       info.redactMap(r.Data),
+			Path:       r.Path,
+			Type:       "http",
+			Passed:     r.Passed(),
+			DurationMs: float64(r.Duration.Microseconds()) / 1000,
+		}
+		if r.Request != nil && r.Request.Type == "grpc" {
+			entry.Type = "grpc"
+		}
+		if r.Error != nil {
+			entry.Error = info.redact(r.Error.Error())
+		}
+		if r.ScriptError != nil {
+			entry.ScriptErr = info.redact(r.ScriptError.Error())
+		}
+		for _, line := range r.Logs {
+			entry.Logs = append(entry.Logs, info.redact(line))
+		}
+		if r.HttpData != nil {
+			entry.Request = jsonReportRequest{
+				Method:   r.HttpData.Method,
+				URL:      info.redact(r.HttpData.URL),
+				Headers:  info.redactMap(r.HttpData.Headers),
+				AuthType: r.HttpData.AuthType,
+				Body:     info.redact(r.HttpData.Body),
+			}
+		} else if r.GrpcData != nil {
+			entry.Request = jsonReportRequest{
+				Method:   r.GrpcData.Method,
+				Server:   info.redact(r.GrpcData.Server),
+				Metadata: info.redactMap(r.GrpcData.Metadata),
+				Body:     info.redact(r.GrpcData.Body),
+			}
+		}
+		if r.Error == nil {
+			headers := make(http.Header, len(r.ResponseHeaders))
+			for k, values := range r.ResponseHeaders {
+				for _, v := range values {
+					headers[k] = append(headers[k], info.redact(v))
+				}
+			}
+			entry.Response = &jsonReportResponse{
+				Status:     r.Status,
+				StatusCode: r.StatusCode,
+				Headers:    headers,
+				Body:       info.redact(string(r.ResponseBody)),
+			}
+		}
+		for _, as := range r.Assertions {
+			entry.Assertions = append(entry.Assertions, jsonReportAssertion{
+				Assertion: info.redact(as.Assertion.String()),
+				Passed:    as.Passed,
+				Actual:    info.redact(as.Actual),
+				Message:   info.redact(as.Message),
+			})
+		}
+		report.Results = append(report.Results, entry)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling JSON report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing JSON report: %w", err)
+	}
+	return nil
+}
+
+// writeRunReports writes whichever reports have a non-empty path.
+// writeRunReports menulis laporan yang path-nya tidak kosong.
+func writeRunReports(junitPath, jsonPath string, info RunReportInfo, results []RunResult) error {
+	if junitPath != "" {
+		if err := writeJUnitReport(junitPath, info, results); err != nil {
+			return err
+		}
+	}
+	if jsonPath != "" {
+		if err := writeJSONReport(jsonPath, info, results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RunOptions controls how a collection folder is executed.
+// RunOptions mengatur bagaimana sebuah folder collection dijalankan.
+type RunOptions struct {
+	Iterations    int
+	StopOnFailure bool
+	Variables     map[string]string           // Base variables, usually from the selected environment / Variabel dasar, biasanya dari environment yang dipilih
+	Scopes        []variableScope             // Global and folder scopes of the node being run / Scope global dan folder dari node yang dijalankan
+	Commands      map[string]*CommandVariable // Command variables of the selected environment / Variabel perintah dari environment yang dipilih
+	DataRows      []map[string]string         // Optional data-driven rows, one per iteration / Baris data opsional, satu per iterasi
+}
+
+// RunResult is the outcome of a single request executed by the collection runner.
+// RunResult adalah hasil dari satu request yang dijalankan oleh collection runner.
+type RunResult struct {
+	Iteration   int
+	Data        map[string]string // Data row bound for this iteration, if any / Baris data yang dipakai untuk iterasi ini, jika ada
+	Path        string            // Slash-separated path relative to the folder being run / Path dipisah garis miring relatif terhadap folder yang dijalankan
+	Request     *Request
+	Status      string
+	StatusCode  int
+	Duration    time.Duration
+	Error       error
+	ScriptError error // Post-response script failure; the response is still available / Kegagalan script post-response; response tetap tersedia
+	Assertions  []AssertionResult
+	Logs        []string // Script console output / Output console dari script
+
+	// Details kept for machine-readable reports / Detail yang disimpan untuk laporan yang dapat dibaca mesin
+	StartedAt       time.Time
+	HttpData        *HttpRequestData
+	GrpcData        *GrpcRequestData
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// Passed reports whether the request succeeded and all of its assertions held. Requests
+// without assertions pass when no error occurred and the HTTP status is below 400. /
+// Passed melaporkan apakah request berhasil dan semua assertion-nya terpenuhi. Request
+// tanpa assertion dianggap lulus jika tidak ada error dan status HTTP di bawah 400.
+func (r RunResult) Passed() bool {
+	if r.Error != nil || r.ScriptError != nil {
+		return false
+	}
+	if len(r.Assertions) == 0 {
+		return r.StatusCode < 400
+	}
+	for _, as := range r.Assertions {
+		if !as.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// runItem is a request scheduled for execution together with its display path and the
+// global and folder variable scopes it inherits. /
+// runItem adalah request yang dijadwalkan untuk dijalankan beserta path tampilannya dan scope
+// variabel global serta folder yang diwarisinya.
+type runItem struct {
+	path    string
+	request *Request
+	scopes  []variableScope
+}
+
+// collectRunItems flattens a collection node into its requests in tree order. scopes are the
+// scopes node inherits; subfolders add their own. /
+// collectRunItems meratakan sebuah node collection menjadi request-request sesuai urutan tree.
+// scopes adalah scope yang diwarisi node; subfolder menambahkan scope-nya sendiri.
+func collectRunItems(node *CollectionNode, prefix string, scopes []variableScope) []runItem {
+	if !node.IsFolder {
+		if node.Request == nil {
+			return nil
+		}
+		return []runItem{{path: node.Name, request: node.Request, scopes: scopes}}
+	}
+
+	var items []runItem
+	for _, child := range node.Children {
+		path := child.Name
+		if prefix != "" {
+			path = prefix + "/" + child.Name
+		}
+		if child.IsFolder {
+			items = append(items, collectRunItems(child, path, withScope(scopes, folderScope(child)))...)
+		} else if child.Request != nil {
+			items = append(items, runItem{path: path, request: child.Request, scopes: scopes})
+		}
+	}
+	return items
+}
+
+// runCollection executes every request under node in order for the requested number of
+// iterations. When data rows are given, there is one iteration per row and the row's
+// columns are layered over the base variables. onResult, if not nil, is called after
+// each request completes. /
+// runCollection menjalankan setiap request di bawah node secara berurutan sebanyak iterasi
+// yang diminta. Jika baris data diberikan, ada satu iterasi per baris dan kolom-kolom baris
+// tersebut ditumpuk di atas variabel dasar. onResult, jika tidak nil, dipanggil setelah
+// setiap request selesai.
+func runCollection(ctx context.Context, node *CollectionNode, opts RunOptions, onResult func(RunResult)) []RunResult {
+	items := collectRunItems(node, "", opts.Scopes)
+	iterations := opts.Iterations
+	if len(opts.DataRows) > 0 {
+		iterations = len(opts.DataRows)
+	}
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	pool := newGrpcSessionPool()
+	defer pool.Close()
+
+	log.Printf("INFO: Running collection '%s': %d requests, %d iterations", node.Name, len(items), iterations)
+
+	var results []RunResult
+	for iter := 1; iter <= iterations; iter++ {
+		// Captures and script variables only carry over within the same iteration.
+		// Capture dan variabel script hanya berlaku di dalam iterasi yang sama.
+		vars := make(map[string]string, len(opts.Variables))
+		for k, v := range opts.Variables {
+			vars[k] = v
+		}
+		sv := newScriptVariables(vars)
+		sv.commands = opts.Commands
+		var row map[string]string
+		if len(opts.DataRows) > 0 {
+			row = opts.DataRows[iter-1]
+			sv.overrides = maps.Clone(row)
+		}
+
+		for _, item := range items {
+			if item.request.DelayMs > 0 {
+				select {
+				case <-time.After(time.Duration(item.request.DelayMs) * time.Millisecond):
+				case <-ctx.Done():
+					return results
+				}
+			}
+			if ctx.Err() != nil {
+				return results
+			}
+
+			console := &scriptConsole{}
+			result := runRequest(pool, item.request, item.scopes, sv, console)
+			result.Logs = console.lines
+			result.Iteration = iter
+			result.Data = row
+			result.Path = item.path
+			results = append(results, result)
+			if onResult != nil {
+				onResult(result)
+			}
+
+			if opts.StopOnFailure && !result.Passed() {
+				log.Printf("WARN: Collection run stopped after failure in '%s'", item.path)
+				return results
+			}
+		}
+	}
+	return results
+}
+
+// runRequest sends a single saved request through the full pipeline: pre-request script,
+// variable substitution, sending, post-response script, captures and assertions. The saved
+// request itself is never modified. Variables are resolved from the inherited scopes, sv (the
+// environment and its command variables), the request's own variables and the overrides in sv;
+// changes from scripts and captures go to sv. /
+// runRequest mengirim satu request tersimpan melalui seluruh pipeline: script pre-request,
+// substitusi variabel, pengiriman, script post-response, capture, dan assertion. Request tersimpan
+// tidak pernah diubah. Variabel di-resolve dari scope yang diwarisi, sv (environment beserta
+// variabel perintahnya), variabel milik request dan override di sv; perubahan dari script dan
+// capture masuk ke sv.
+func runRequest(pool *grpcSessionPool, saved *Request, inherited []variableScope, sv *scriptVariables, console *scriptConsole) RunResult {
+	result := RunResult{Request: saved}
+	req := *saved
+
+	upper := []variableScope{{Name: "environment", Vars: sv.vars}, requestScope(saved), {Name: "overrides", Vars: sv.overrides}}
+	// Command variables sit just below the environment's values, so overrides, captures and
+	// script changes win over them. They are only run when used and not defined higher up.
+	// Variabel perintah berada tepat di bawah nilai environment, sehingga override, capture dan
+	// perubahan script menang atasnya. Hanya dijalankan jika dipakai dan tidak didefinisikan di atasnya.
+	defined := mergeScopes(upper)
+	pending := make(map[string]*CommandVariable)
+	for name, cv := range sv.commands {
+		if _, ok := defined[name]; !ok {
+			pending[name] = cv
+		}
+	}
+	commandVars, err := resolveCommandVariables(pending, saved, mergeScopes(append(inherited[:len(inherited):len(inherited)], upper...)))
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	scopes := append(withScope(inherited, variableScope{Name: "commands", Vars: commandVars}), upper...)
+	scoped := newScriptVariables(mergeScopes(scopes))
+	defer sv.adopt(scoped)
+
+	if err := runPreRequestScript(&req, scoped, console); err != nil {
+		result.Error = err
+		return result
+	}
+
+	var snapshot *responseSnapshot
+	if req.Type == "grpc" {
+		data, err := buildGrpcRequestData(&req, scoped.vars)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		result.GrpcData = &data
+		result.StartedAt = time.Now()
+		resp := doGrpcRequest(pool, data)
+		result.Duration = resp.Duration
+		if resp.Error != nil {
+			result.Error = resp.Error
+			return result
+		}
+		result.Status = "OK"
+		result.ResponseBody = resp.Body
+		snapshot = &responseSnapshot{Status: "OK", Body: resp.Body, Duration: resp.Duration}
+	} else {
+		data, err := buildHttpRequestData(&req, scoped.vars)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		result.HttpData = &data
+		result.StartedAt = time.Now()
+		resp := doHttpRequest(data)
+		result.Duration = resp.Duration
+		if resp.Error != nil {
+			result.Error = resp.Error
+			return result
+		}
+		result.Status = resp.Status
+		result.StatusCode = resp.StatusCode
+		result.ResponseHeaders = resp.Headers
+		result.ResponseBody = resp.Body
+		snapshot = &responseSnapshot{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Headers:    resp.Headers,
+			Body:       resp.Body,
+			Duration:   resp.Duration,
+		}
+	}
+
+	if err := runPostResponseScript(&req, snapshot, scoped, console); err != nil {
+		result.ScriptError = err
+	}
+	applyCaptures(req.Captures, snapshot, scoped)
+	for _, as := range req.Assertions {
+		result.Assertions = append(result.Assertions, evaluateAssertion(as, snapshot))
+	}
+	return result
+}
+
+// buildHttpRequestData converts a saved HTTP request into HttpRequestData with vars applied.
+// buildHttpRequestData mengkonversi request HTTP tersimpan menjadi HttpRequestData dengan vars yang diterapkan.
+func buildHttpRequestData(req *Request, vars map[string]string) (HttpRequestData, error) {
+	data := HttpRequestData{
+		Method:    req.Method,
+		URL:       substituteVariables(req.URL, vars),
+		Body:      substituteVariables(req.Body, vars),
+		AuthType:  getAuthTypeName(req.AuthType),
+		AuthToken: substituteVariables(req.AuthToken, vars),
+		AuthUser:  substituteVariables(req.AuthUser, vars),
+		AuthPass:  substituteVariables(req.AuthPass, vars),
+		Headers:   make(map[string]string),
+	}
+	if data.Method == "" {
+		data.Method = "GET"
+	}
+	if data.URL == "" {
+		return data, fmt.Errorf("URL is required")
+	}
+
+	if strings.TrimSpace(req.HeadersRaw) != "" {
+		headersJSON := substituteVariables(req.HeadersRaw, vars)
+		if err := json.Unmarshal([]byte(headersJSON), &data.Headers); err != nil {
+			return data, fmt.Errorf("parsing headers: %w", err)
+		}
+	} else {
+		for k, v := range req.Headers {
+			data.Headers[k] = substituteVariables(v, vars)
+		}
+	}
+	texts := []string{data.URL, data.Body, data.AuthToken, data.AuthUser, data.AuthPass}
+	for _, v := range data.Headers {
+		texts = append(texts, v)
+	}
+	return data, checkSecretsDecrypted(texts...)
+}
+
+// buildGrpcRequestData converts a saved gRPC request into GrpcRequestData with vars applied.
+// buildGrpcRequestData mengkonversi request gRPC tersimpan menjadi GrpcRequestData dengan vars yang diterapkan.
+func buildGrpcRequestData(req *Request, vars map[string]string) (GrpcRequestData, error) {
+	data := GrpcRequestData{
+		Server: substituteVariables(req.GrpcServer, vars),
+		Method: req.GrpcMethod,
+		Body:   substituteVariables(req.Body, vars),
+	}
+	if data.Server == "" || data.Method == "" {
+		return data, fmt.Errorf("server and method are required")
+	}
+
+	meta, err := parseGrpcMetadata(substituteVariables(req.GrpcMetadata, vars))
+	if err != nil {
+		return data, err
+	}
+	data.Metadata = meta
+	texts := []string{data.Server, data.Body}
+	for _, v := range meta {
+		texts = append(texts, v)
+	}
+	return data, checkSecretsDecrypted(texts...)
+}
+
+// summarizeRun counts passed and failed results and sums their durations.
+// summarizeRun menghitung hasil yang lulus dan gagal serta menjumlahkan durasinya.
+func summarizeRun(results []RunResult) (passed, failed int, total time.Duration) {
+	for _, r := range results {
+		if r.Passed() {
+			passed++
+		} else {
+			failed++
+		}
+		total += r.Duration
+	}
+	return passed, failed, total
+}
+
+// IterationSummary aggregates the results of a single iteration of a collection run.
+// IterationSummary merangkum hasil dari satu iterasi collection run.
+type IterationSummary struct {
+	Iteration int
+	Data      map[string]string
+	Passed    int
+	Failed    int
+	Duration  time.Duration
+}
+
+// summarizeIterations groups results by iteration, in iteration order.
+// summarizeIterations mengelompokkan hasil berdasarkan iterasi, sesuai urutan iterasi.
+func summarizeIterations(results []RunResult) []IterationSummary {
+	var summaries []IterationSummary
+	for _, r := range results {
+		if len(summaries) == 0 || summaries[len(summaries)-1].Iteration != r.Iteration {
+			summaries = append(summaries, IterationSummary{Iteration: r.Iteration, Data: r.Data})
+		}
+		s := &summaries[len(summaries)-1]
+		if r.Passed() {
+			s.Passed++
+		} else {
+			s.Failed++
+		}
+		s.Duration += r.Duration
+	}
+	return summaries
+}
+
+// formatDataRow renders a data row as sorted `key=value` pairs for display.
+// formatDataRow menampilkan baris data sebagai pasangan `key=value` yang terurut.
+func formatDataRow(row map[string]string) string {
+	keys := make([]string, 0, len(row))
+	for k := range row {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + row[k]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// failureReason returns a short description of why a result failed, or "" if it passed.
+// failureReason mengembalikan deskripsi singkat alasan sebuah hasil gagal, atau "" jika lulus.
+func failureReason(r RunResult) string {
+	if r.Error != nil {
+		return r.Error.Error()
+	}
+	var messages []string
+	if r.ScriptError != nil {
+		messages = append(messages, r.ScriptError.Error())
+	}
+	for _, as := range r.Assertions {
+		if !as.Passed {
+			messages = append(messages, as.Message)
+		}
+	}
+	if len(messages) == 0 && !r.Passed() {
+		return fmt.Sprintf("unexpected status %s", r.Status)
+	}
+	return strings.Join(messages, "; ")
+}
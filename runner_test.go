@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newEchoServer returns a server whose response body is the value of the "v" query parameter,
+// with the X-Next header set to the value a capture should pick up.
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Next", "captured")
+		fmt.Fprint(w, r.URL.Query().Get("v"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunRequestVariablePrecedence(t *testing.T) {
+	server := newEchoServer(t)
+
+	// A command that fails shows whether runRequest ran it: it must only run when no
+	// higher scope defines the variable.
+	failing := &CommandVariable{Command: "exit 3"}
+	echo := &CommandVariable{Command: "echo command"}
+
+	tests := []struct {
+		name      string
+		global    map[string]string
+		folder    map[string]string
+		commands  map[string]*CommandVariable
+		env       map[string]string
+		request   map[string]string
+		overrides map[string]string
+		want      string
+	}{
+		{name: "global", global: map[string]string{"v": "global"}, want: "global"},
+		{name: "folder over global", global: map[string]string{"v": "global"}, folder: map[string]string{"v": "folder"}, want: "folder"},
+		{name: "command over folder", folder: map[string]string{"v": "folder"}, commands: map[string]*CommandVariable{"v": echo}, want: "command"},
+		{name: "environment over command", commands: map[string]*CommandVariable{"v": failing}, env: map[string]string{"v": "environment"}, want: "environment"},
+		{name: "request over environment", commands: map[string]*CommandVariable{"v": failing}, env: map[string]string{"v": "environment"}, request: map[string]string{"v": "request"}, want: "request"},
+		{name: "override over request", commands: map[string]*CommandVariable{"v": failing}, request: map[string]string{"v": "request"}, overrides: map[string]string{"v": "override"}, want: "override"},
+		{name: "undefined is left as is", want: "{{v}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &Request{Type: "http", Method: "GET", URL: server.URL + "/?v={{v}}", Variables: tt.request}
+			inherited := []variableScope{{Name: "global", Vars: tt.global}, {Name: "folder", Vars: tt.folder}}
+			env := tt.env
+			if env == nil {
+				env = make(map[string]string)
+			}
+			sv := newScriptVariables(env)
+			sv.commands = tt.commands
+			sv.overrides = tt.overrides
+
+			result := runRequest(nil, req, inherited, sv, nil)
+			if result.Error != nil {
+				t.Fatalf("runRequest error: %v", result.Error)
+			}
+			if got := string(result.ResponseBody); got != tt.want {
+				t.Errorf("request used %q, want %q", got, tt.want)
+			}
+			if len(sv.set) != 0 || len(sv.removed) != 0 {
+				t.Errorf("runRequest recorded changes %v %v without a script or capture", sv.set, sv.removed)
+			}
+		})
+	}
+}
+
+func TestRunRequestScriptAndCaptureChanges(t *testing.T) {
+	server := newEchoServer(t)
+
+	tests := []struct {
+		name          string
+		script        string
+		captures      []Capture
+		wantBody      string
+		wantVar       string
+		wantOverrides bool
+	}{
+		{
+			name:          "override stays without changes",
+			wantBody:      "override",
+			wantVar:       "environment",
+			wantOverrides: true,
+		},
+		{
+			name:     "script change wins over the override",
+			script:   `env.set("v", "script")`,
+			wantBody: "script",
+			wantVar:  "script",
+		},
+		{
+			name:     "capture replaces the override for later requests",
+			captures: []Capture{{Variable: "v", Source: "header.X-Next"}},
+			wantBody: "override",
+			wantVar:  "captured",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &Request{
+				Type:             "http",
+				Method:           "GET",
+				URL:              server.URL + "/?v={{v}}",
+				Variables:        map[string]string{"v": "request"},
+				PreRequestScript: tt.script,
+				Captures:         tt.captures,
+			}
+			sv := newScriptVariables(map[string]string{"v": "environment"})
+			sv.overrides = map[string]string{"v": "override"}
+
+			result := runRequest(nil, req, nil, sv, nil)
+			if result.Error != nil || result.ScriptError != nil {
+				t.Fatalf("runRequest errors: %v, %v", result.Error, result.ScriptError)
+			}
+			if got := string(result.ResponseBody); got != tt.wantBody {
+				t.Errorf("request used %q, want %q", got, tt.wantBody)
+			}
+			if sv.vars["v"] != tt.wantVar {
+				t.Errorf("environment value = %q, want %q", sv.vars["v"], tt.wantVar)
+			}
+			if _, ok := sv.overrides["v"]; ok != tt.wantOverrides {
+				t.Errorf("override kept = %v, want %v", ok, tt.wantOverrides)
+			}
+		})
+	}
+}
+
+func TestRunRequestDoesNotLeakScopedVariables(t *testing.T) {
+	server := newEchoServer(t)
+	req := &Request{Type: "http", Method: "GET", URL: server.URL + "/?v={{v}}", Variables: map[string]string{"v": "request"}}
+	inherited := []variableScope{{Name: "folder", Vars: map[string]string{"folderOnly": "1"}}}
+	sv := newScriptVariables(map[string]string{"envOnly": "1"})
+
+	runRequest(nil, req, inherited, sv, nil)
+	if len(sv.vars) != 1 || sv.vars["envOnly"] != "1" {
+		t.Errorf("environment after the run = %v, want only its own variable", sv.vars)
+	}
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// selectedCollectionNode returns the collection node under the cursor in the collections tree.
+// selectedCollectionNode mengembalikan node collection yang sedang dipilih di tree Collections.
+func (a *App) selectedCollectionNode() *CollectionNode {
+	treeNode := a.collectionsTree.GetCurrentNode()
+	if treeNode == nil {
+		return nil
+	}
+	node, _ := treeNode.GetReference().(*CollectionNode)
+	return node
+}
+
+// showRunnerModal displays the options form for running a collection folder or request.
+// showRunnerModal menampilkan form opsi untuk menjalankan sebuah folder atau request collection.
+func (a *App) showRunnerModal(node *CollectionNode) {
+	if node == nil {
+		return
+	}
+
+	envNames := make([]string, len(a.environments))
+	for i, env := range a.environments {
+		envNames[i] = env.Name
+	}
+
+	envDrop := tview.NewDropDown().SetLabel("Environment").SetOptions(envNames, nil)
+	if a.activeEnvIndex < len(envNames) {
+		envDrop.SetCurrentOption(a.activeEnvIndex)
+	}
+	iterInput := tview.NewInputField().SetLabel("Iterations").SetText("1").SetFieldWidth(6).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+	stopCheck := tview.NewCheckbox().SetLabel("Stop on failure")
+	dataInput := tview.NewInputField().SetLabel("Data file (CSV/JSON)").SetFieldWidth(30).
+		SetPlaceholder("optional, one iteration per row")
+	junitInput := tview.NewInputField().SetLabel("JUnit report").SetFieldWidth(30).
+		SetPlaceholder("optional, e.g. results.xml")
+	jsonInput := tview.NewInputField().SetLabel("JSON report").SetFieldWidth(30).
+		SetPlaceholder("optional, e.g. results.json")
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(envDrop).
+		AddFormItem(iterInput).
+		AddFormItem(stopCheck).
+		AddFormItem(dataInput).
+		AddFormItem(junitInput).
+		AddFormItem(jsonInput).
+		AddButton("Run", func() {
+			iterations, _ := strconv.Atoi(iterInput.GetText())
+			opts := RunOptions{
+				Iterations:    iterations,
+				StopOnFailure: stopCheck.IsChecked(),
+				Variables:     make(map[string]string),
+				Scopes:        a.collectionScopes(node),
+			}
+			if path := strings.TrimSpace(dataInput.GetText()); path != "" {
+				rows, err := loadDataRows(path)
+				if err != nil {
+					form.SetTitle(fmt.Sprintf(" [red]%v ", err))
+					return
+				}
+				opts.DataRows = rows
+			}
+			info := RunReportInfo{Collection: node.Name}
+			if envIndex, envName := envDrop.GetCurrentOption(); envIndex >= 0 && envIndex < len(a.environments) {
+				opts.Variables = a.environments[envIndex].allVariables()
+				opts.Commands = a.environments[envIndex].Commands
+				info.Environment = envName
+			}
+			reports := runnerReportPaths{
+				junit: strings.TrimSpace(junitInput.GetText()),
+				json:  strings.TrimSpace(jsonInput.GetText()),
+			}
+			a.rootPages.RemovePage("runnerModal")
+			a.startCollectionRun(node, opts, info, reports)
+		}).
+		AddButton("Cancel", func() {
+			a.rootPages.RemovePage("runnerModal")
+		})
+	form.SetCancelFunc(func() {
+		a.rootPages.RemovePage("runnerModal")
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Run: %s ", node.Name))
+	modal := a.createModal(form, 70, 17)
+	a.rootPages.AddPage("runnerModal", modal, true, true)
+	a.app.SetFocus(form)
+}
+
+// cloneCollectionNode returns a deep copy of node and its children. The recorded response, which
+// only history entries keep, is left out. /
+// cloneCollectionNode mengembalikan salinan lengkap dari node beserta anak-anaknya. Response yang
+// direkam, yang hanya disimpan entri History, tidak ikut disalin.
+func cloneCollectionNode(node *CollectionNode) *CollectionNode {
+	copied := *node
+	copied.Variables = maps.Clone(node.Variables)
+	if node.Request != nil {
+		req := *node.Request
+		req.Headers = maps.Clone(req.Headers)
+		req.Variables = maps.Clone(req.Variables)
+		req.Captures = slices.Clone(req.Captures)
+		req.Assertions = slices.Clone(req.Assertions)
+		req.Response = nil
+		copied.Request = &req
+	}
+	copied.Children = make([]*CollectionNode, len(node.Children))
+	for i, child := range node.Children {
+		copied.Children[i] = cloneCollectionNode(child)
+	}
+	return &copied
+}
+
+// runnerReportPaths holds the optional report files requested for a run.
+// runnerReportPaths menyimpan file laporan opsional yang diminta untuk sebuah run.
+type runnerReportPaths struct {
+	junit string
+	json  string
+}
+
+// startCollectionRun executes the run in the background and streams the results into a table.
+// startCollectionRun menjalankan run di background dan menampilkan hasilnya secara bertahap ke tabel.
+func (a *App) startCollectionRun(node *CollectionNode, opts RunOptions, info RunReportInfo, reports runnerReportPaths) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// The tree and the environment can still be edited, saved or reloaded while the run reads
+	// them in the background, so the run gets its own copies.
+	// Tree dan environment masih dapat diubah, disimpan atau dimuat ulang selama run membacanya
+	// di background, sehingga run mendapat salinannya sendiri.
+	node = cloneCollectionNode(node)
+	scopes := make([]variableScope, len(opts.Scopes))
+	for i, scope := range opts.Scopes {
+		scopes[i] = variableScope{Name: scope.Name, Vars: maps.Clone(scope.Vars)}
+	}
+	opts.Scopes = scopes
+	commands := make(map[string]*CommandVariable, len(opts.Commands))
+	for name, cv := range opts.Commands {
+		copied := *cv
+		commands[name] = &copied
+	}
+	opts.Commands = commands
+
+	summary := tview.NewTextView().SetDynamicColors(true).SetText("[yellow]Running...")
+	table := tview.NewTable().SetBorders(false).SetSelectable(true, false).SetFixed(1, 0)
+	for col, title := range []string{"#", "Iter", "Request", "Status", "Duration", "Result"} {
+		table.SetCell(0, col, tview.NewTableCell(title).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	closeRunner := func() {
+		cancel()
+		a.rootPages.RemovePage("runnerResults")
+		a.app.SetFocus(a.collectionsTree)
+	}
+	stopBtn := tview.NewButton("Stop").SetSelectedFunc(cancel)
+	closeBtn := tview.NewButton("Close (Esc)").SetSelectedFunc(closeRunner)
+	buttons := tview.NewFlex().
+		AddItem(tview.NewBox(), 0, 1, false).
+		AddItem(stopBtn, 8, 0, false).
+		AddItem(closeBtn, 13, 0, false)
+
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(buttons, 1, 0, false)
+	content.SetBorder(true).SetTitle(fmt.Sprintf(" Runner: %s ", node.Name))
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			closeRunner()
+			return nil
+		}
+		return event
+	})
+
+	modal := a.createModal(content, 110, 30)
+	a.rootPages.AddPage("runnerResults", modal, true, true)
+	a.app.SetFocus(table)
+
+	// Iteration separators are only useful when there is more than one iteration.
+	// Pemisah iterasi hanya berguna jika ada lebih dari satu iterasi.
+	showIterations := opts.Iterations > 1 || len(opts.DataRows) > 0
+
+	var results []RunResult
+	info.StartedAt = time.Now()
+	go func() {
+		runCollection(ctx, node, opts, func(r RunResult) {
+			a.app.QueueUpdateDraw(func() {
+				if showIterations && (len(results) == 0 || results[len(results)-1].Iteration != r.Iteration) {
+					addIterationRow(table, r)
+				}
+				results = append(results, r)
+				a.appendConsole(r.Logs)
+				addRunResultRow(table, len(results), r)
+				passed, failed, total := summarizeRun(results)
+				summary.SetText(fmt.Sprintf("[yellow]Running...[-] [green]%d passed[-] | [red]%d failed[-] | Total: [cyan]%v[-]", passed, failed, total))
+			})
+		})
+		a.app.QueueUpdateDraw(func() {
+			info.FinishedAt = time.Now()
+			info.Redactor = a.secretRedactor()
+			reportInfo := ""
+			if reports.junit != "" || reports.json != "" {
+				if err := writeRunReports(reports.junit, reports.json, info, results); err != nil {
+					log.Printf("ERROR: Failed to write run reports: %v", err)
+					reportInfo = fmt.Sprintf(" | [red]Report error: %v[-]", err)
+				} else {
+					reportInfo = " | Reports written"
+				}
+			}
+			passed, failed, total := summarizeRun(results)
+			state := "[green]Finished[-]"
+			if ctx.Err() != nil {
+				state = "[yellow]Stopped[-]"
+			}
+			iterationInfo := ""
+			if showIterations {
+				iterations := summarizeIterations(results)
+				failedIterations := 0
+				for _, it := range iterations {
+					if it.Failed > 0 {
+						failedIterations++
+					}
+				}
+				iterationInfo = fmt.Sprintf(" | Iterations: %d ([red]%d failed[-])", len(iterations), failedIterations)
+			}
+			summary.SetText(fmt.Sprintf("%s %d requests | [green]%d passed[-] | [red]%d failed[-] | Total: [cyan]%v[-]%s", state, len(results), passed, failed, total, iterationInfo+reportInfo))
+		})
+	}()
+}
+
+// addRunResultRow appends a single runner result to the results table.
+// addRunResultRow menambahkan satu hasil runner ke tabel hasil.
+func addRunResultRow(table *tview.Table, index int, r RunResult) {
+	row := table.GetRowCount()
+	resultCell := tview.NewTableCell("PASS").SetTextColor(tcell.ColorGreen)
+	if !r.Passed() {
+		resultCell = tview.NewTableCell("FAIL " + failureReason(r)).SetTextColor(tcell.ColorRed)
+	}
+	status := r.Status
+	if status == "" {
+		status = "-"
+	}
+	table.SetCell(row, 0, tview.NewTableCell(strconv.Itoa(index)))
+	table.SetCell(row, 1, tview.NewTableCell(strconv.Itoa(r.Iteration)))
+	table.SetCell(row, 2, tview.NewTableCell(r.Path).SetMaxWidth(40))
+	table.SetCell(row, 3, tview.NewTableCell(status))
+	table.SetCell(row, 4, tview.NewTableCell(r.Duration.Round(time.Millisecond).String()).SetAlign(tview.AlignRight))
+	table.SetCell(row, 5, resultCell.SetExpansion(1))
+}
+
+// addIterationRow appends a separator row marking the start of an iteration and its data row.
+// addIterationRow menambahkan baris pemisah yang menandai awal sebuah iterasi beserta baris datanya.
+func addIterationRow(table *tview.Table, r RunResult) {
+	label := "── Iteration"
+	if len(r.Data) > 0 {
+		label += " " + formatDataRow(r.Data)
+	}
+	row := table.GetRowCount()
+	table.SetCell(row, 1, tview.NewTableCell(strconv.Itoa(r.Iteration)).SetTextColor(tcell.ColorAqua).SetSelectable(false))
+	table.SetCell(row, 2, tview.NewTableCell(label).
+		SetTextColor(tcell.ColorAqua).
+		SetMaxWidth(40).
+		SetSelectable(false))
+}
+
+// showRequestTestsModal displays a form to edit the delay, captures and assertions of a saved request.
+// showRequestTestsModal menampilkan form untuk mengedit jeda, capture, dan assertion dari request tersimpan.
+func (a *App) showRequestTestsModal(node *CollectionNode) {
+	if node == nil || node.IsFolder || node.Request == nil {
+		return
+	}
+	req := node.Request
+
+	delayInput := tview.NewInputField().SetLabel("Delay (ms) ").
+		SetText(strconv.Itoa(req.DelayMs)).
+		SetFieldWidth(8).
+		SetAcceptanceFunc(tview.InputFieldInteger)
+
+	var captureLines, assertionLines []string
+	for _, c := range req.Captures {
+		captureLines = append(captureLines, c.String())
+	}
+	for _, as := range req.Assertions {
+		assertionLines = append(assertionLines, as.String())
+	}
+
+	capturesText := tview.NewTextArea().
+		SetPlaceholder("One per line, e.g.\ntoken = json.data.token\nrequestId = header.X-Request-Id")
+	capturesText.SetText(strings.Join(captureLines, "\n"), false)
+	capturesText.SetBorder(true).SetTitle(" Captures ")
+
+	assertionsText := tview.NewTextArea().
+		SetPlaceholder("One per line, e.g.\nstatus == 200\njson.data.id exists\nbody contains ok\nduration < 500")
+	assertionsText.SetText(strings.Join(assertionLines, "\n"), false)
+	assertionsText.SetBorder(true).SetTitle(" Assertions ")
+
+	errorText := tview.NewTextView().SetDynamicColors(true)
+
+	closeModal := func() {
+		a.rootPages.RemovePage("requestTestsModal")
+		a.app.SetFocus(a.collectionsTree)
+	}
+	saveBtn := tview.NewButton("Save").SetSelectedFunc(func() {
+		var captures []Capture
+		for _, line := range strings.Split(capturesText.GetText(), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			c, err := parseCapture(line)
+			if err != nil {
+				errorText.SetText("[red]" + err.Error())
+				return
+			}
+			captures = append(captures, c)
+		}
+		var assertions []Assertion
+		for _, line := range strings.Split(assertionsText.GetText(), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			as, err := parseAssertion(line)
+			if err != nil {
+				errorText.SetText("[red]" + err.Error())
+				return
+			}
+			assertions = append(assertions, as)
+		}
+
+		req.DelayMs, _ = strconv.Atoi(delayInput.GetText())
+		req.Captures = captures
+		req.Assertions = assertions
+		a.saveCollections()
+		closeModal()
+	})
+	cancelBtn := tview.NewButton("Cancel").SetSelectedFunc(closeModal)
+
+	buttons := tview.NewFlex().
+		AddItem(errorText, 0, 1, false).
+		AddItem(saveBtn, 8, 0, false).
+		AddItem(cancelBtn, 10, 0, false)
+
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(delayInput, 1, 0, true).
+		AddItem(capturesText, 0, 1, false).
+		AddItem(assertionsText, 0, 1, false).
+		AddItem(buttons, 1, 0, false)
+	content.SetBorder(true).SetTitle(fmt.Sprintf(" Tests: %s ", node.Name))
+
+	focusables := []tview.Primitive{delayInput, capturesText, assertionsText, saveBtn, cancelBtn}
+	content.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			for i, p := range focusables {
+				if p.HasFocus() {
+					step := 1
+					if event.Key() == tcell.KeyBacktab {
+						step = len(focusables) - 1
+					}
+					a.app.SetFocus(focusables[(i+step)%len(focusables)])
+					return nil
+				}
+			}
+		}
+		return event
+	})
+
+	modal := a.createModal(content, 80, 24)
+	a.rootPages.AddPage("requestTestsModal", modal, true, true)
+	a.app.SetFocus(delayInput)
+}
@@ -15,9 +15,9 @@ func (a *App) generateGhzCommand() string {
 	metaText := a.grpcRequestMeta.GetText()
 	bodyText := a.grpcRequestBody.GetText()
 
-	server = a.replaceVariables(server)
-	metaText = a.replaceVariables(metaText)
-	bodyText = a.replaceVariables(bodyText)
+	server = a.replaceVariables(server, "grpc")
+	metaText = a.replaceVariables(metaText, "grpc")
+	bodyText = a.replaceVariables(bodyText, "grpc")
 
 	if server == "" || method == "" {
 		return "# Error: Server and Method must be filled"
@@ -58,9 +58,9 @@ func (a *App) generateGrpcurlCommand() string {
 	metaText := a.grpcRequestMeta.GetText()
 	bodyText := a.grpcRequestBody.GetText()
 
-	server = a.replaceVariables(server)
-	metaText = a.replaceVariables(metaText)
-	bodyText = a.replaceVariables(bodyText)
+	server = a.replaceVariables(server, "grpc")
+	metaText = a.replaceVariables(metaText, "grpc")
+	bodyText = a.replaceVariables(bodyText, "grpc")
 
 	if server == "" || method == "" {
 		return "# Error: Server and Method must be filled"
@@ -100,9 +100,9 @@ func (a *App) generateCurlCommand() string {
 	headersText := a.headersText.GetText()
 	bodyText := a.bodyText.GetText()
 
-	url = a.replaceVariables(url)
-	headersText = a.replaceVariables(headersText)
-	bodyText = a.replaceVariables(bodyText)
+	url = a.replaceVariables(url, "http")
+	headersText = a.replaceVariables(headersText, "http")
+	bodyText = a.replaceVariables(bodyText, "http")
 
 	if url == "" {
 		return "# Error: URL must be filled"
@@ -124,13 +124,13 @@ func (a *App) generateCurlCommand() string {
 	_, authType := a.authType.GetCurrentOption()
 	switch authType {
 	case "Bearer Token":
-		token := a.replaceVariables(a.authToken.GetText())
+		token := a.replaceVariables(a.maskedAuthValue(a.authToken.GetText()), "http")
 		if token != "" {
 			cmd = append(cmd, fmt.Sprintf("-H 'Authorization: Bearer %s'", token))
 		}
 	case "Basic Auth":
-		user := a.replaceVariables(a.authUser.GetText())
-		pass := a.replaceVariables(a.authPass.GetText())
+		user := a.replaceVariables(a.authUser.GetText(), "http")
+		pass := a.replaceVariables(a.maskedAuthValue(a.authPass.GetText()), "http")
 		if user != "" {
 			cmd = append(cmd, fmt.Sprintf("-u '%s:%s'", user, pass))
 		}
@@ -198,9 +198,24 @@ func (a *App) showGenerateScriptModal() {
 	closeBtn := tview.NewButton("Close (Esc)").SetSelectedFunc(func() {
 		a.rootPages.RemovePage("scriptModal")
 	})
+	// Secret values are masked in the script until revealed.
+	// Nilai rahasia disamarkan di script sampai ditampilkan.
+	revealLabel := func() string {
+		if a.revealSecrets {
+			return "Hide Secrets"
+		}
+		return "Reveal Secrets"
+	}
+	var revealBtn *tview.Button
+	revealBtn = tview.NewButton(revealLabel()).SetSelectedFunc(func() {
+		a.toggleRevealSecrets()
+		revealBtn.SetLabel(revealLabel())
+		updateScript()
+	})
 
 	buttons := tview.NewFlex().
 		AddItem(tview.NewBox(), 0, 1, false).
+		AddItem(revealBtn, 16, 0, false).
 		AddItem(copyBtn, 15, 0, false).
 		AddItem(closeBtn, 15, 0, false)
 
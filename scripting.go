@@ -0,0 +1,423 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// scriptTimeout bounds how long a single pre-request or post-response script may run.
+// scriptTimeout membatasi berapa lama satu script pre-request atau post-response boleh berjalan.
+const scriptTimeout = 60 * time.Second
+
+// scriptConsole collects the output written by scripts through console.log.
+// scriptConsole mengumpulkan output yang ditulis script melalui console.log.
+type scriptConsole struct {
+	lines []string
+}
+
+// printf appends a formatted line to the console.
+// printf menambahkan satu baris terformat ke console.
+func (c *scriptConsole) printf(format string, args ...interface{}) {
+	line := fmt.Sprintf(format, args...)
+	c.lines = append(c.lines, line)
+	log.Printf("SCRIPT: %s", line)
+}
+
+// scriptVariables is the variable store scripts read and write through `env`. Changes
+// are applied to vars immediately and also recorded so callers can persist them. /
+// scriptVariables adalah penyimpanan variabel yang dibaca dan ditulis script melalui `env`.
+// Perubahan langsung diterapkan ke vars dan juga dicatat agar pemanggil dapat menyimpannya.
+type scriptVariables struct {
+	vars    map[string]string
+	set     map[string]string
+	removed map[string]bool
+
+	// Command variables of the environment, run when a request uses them.
+	// Variabel perintah dari environment, dijalankan saat sebuah request memakainya.
+	commands map[string]*CommandVariable
+
+	// Values from `send --var` or the data row of the iteration. They win over every scope
+	// until a script or capture changes them. /
+	// Nilai dari `send --var` atau baris data dari iterasi. Nilai ini menang atas semua scope
+	// sampai script atau capture mengubahnya.
+	overrides map[string]string
+}
+
+// newScriptVariables wraps vars for use by scripts.
+// newScriptVariables membungkus vars untuk digunakan oleh script.
+func newScriptVariables(vars map[string]string) *scriptVariables {
+	return &scriptVariables{vars: vars, set: make(map[string]string), removed: make(map[string]bool)}
+}
+
+// setVar sets a variable and records the change.
+// setVar mengatur sebuah variabel dan mencatat perubahannya.
+func (sv *scriptVariables) setVar(name, value string) {
+	sv.vars[name] = value
+	sv.set[name] = value
+	delete(sv.removed, name)
+	delete(sv.overrides, name)
+}
+
+// unsetVar removes a variable and records the removal.
+// unsetVar menghapus sebuah variabel dan mencatat penghapusannya.
+func (sv *scriptVariables) unsetVar(name string) {
+	delete(sv.vars, name)
+	delete(sv.set, name)
+	sv.removed[name] = true
+	delete(sv.overrides, name)
+}
+
+// adopt applies and records the changes recorded by other, e.g. those made while a request
+// ran with its scoped variables. /
+// adopt menerapkan dan mencatat perubahan yang dicatat oleh other, mis. perubahan yang dibuat
+// saat sebuah request berjalan dengan variabel scope-nya.
+func (sv *scriptVariables) adopt(other *scriptVariables) {
+	for k := range other.removed {
+		sv.unsetVar(k)
+	}
+	for k, v := range other.set {
+		sv.setVar(k, v)
+	}
+}
+
+// applyTo writes the recorded changes into another variable map, such as the active environment.
+// applyTo menulis perubahan yang dicatat ke map variabel lain, seperti environment aktif.
+func (sv *scriptVariables) applyTo(target map[string]string) bool {
+	for k := range sv.removed {
+		delete(target, k)
+	}
+	for k, v := range sv.set {
+		target[k] = v
+	}
+	return len(sv.set) > 0 || len(sv.removed) > 0
+}
+
+// runPreRequestScript runs req.PreRequestScript, letting it read and modify the outgoing
+// request before variables are substituted. req is modified in place. /
+// runPreRequestScript menjalankan req.PreRequestScript, memungkinkan script membaca dan mengubah
+// request keluar sebelum variabel disubstitusi. req diubah secara langsung.
+func runPreRequestScript(req *Request, sv *scriptVariables, console *scriptConsole) error {
+	if strings.TrimSpace(req.PreRequestScript) == "" {
+		return nil
+	}
+	vm := newScriptRuntime(sv, console)
+	requestObj := requestToScriptObject(vm, req)
+	vm.Set("request", requestObj)
+
+	if err := executeScript(vm, req.PreRequestScript); err != nil {
+		return fmt.Errorf("pre-request script: %w", err)
+	}
+	scriptObjectToRequest(requestObj, req)
+	return nil
+}
+
+// runPostResponseScript runs req.PostResponseScript with read access to the request and response.
+// runPostResponseScript menjalankan req.PostResponseScript dengan akses baca ke request dan response.
+func runPostResponseScript(req *Request, resp *responseSnapshot, sv *scriptVariables, console *scriptConsole) error {
+	if strings.TrimSpace(req.PostResponseScript) == "" {
+		return nil
+	}
+	vm := newScriptRuntime(sv, console)
+	vm.Set("request", requestToScriptObject(vm, req))
+	vm.Set("response", responseToScriptObject(vm, resp))
+
+	if err := executeScript(vm, req.PostResponseScript); err != nil {
+		return fmt.Errorf("post-response script: %w", err)
+	}
+	return nil
+}
+
+// executeScript runs source in vm, interrupting it if it exceeds scriptTimeout.
+// executeScript menjalankan source di vm, dan menghentikannya jika melebihi scriptTimeout.
+func executeScript(vm *goja.Runtime, source string) error {
+	timer := time.AfterFunc(scriptTimeout, func() {
+		vm.Interrupt(fmt.Sprintf("script exceeded %v", scriptTimeout))
+	})
+	defer timer.Stop()
+
+	_, err := vm.RunString(source)
+	return err
+}
+
+// maxRandomHexBytes limits crypto.randomHex, so a script cannot exhaust memory.
+// maxRandomHexBytes membatasi crypto.randomHex, sehingga script tidak dapat menghabiskan memori.
+const maxRandomHexBytes = 1 << 20
+
+// newScriptRuntime creates a sandboxed JavaScript runtime. Scripts have no file system
+// access; the only globals are `env`, `console`, `crypto`, `http`, and `sleep`. /
+// newScriptRuntime membuat runtime JavaScript yang terisolasi. Script tidak memiliki akses
+// file system; global yang tersedia hanya `env`, `console`, `crypto`, `http`, dan `sleep`.
+func newScriptRuntime(sv *scriptVariables, console *scriptConsole) *goja.Runtime {
+	vm := goja.New()
+	vm.SetFieldNameMapper(goja.UncapFieldNameMapper())
+
+	consoleObj := vm.NewObject()
+	logFunc := func(call goja.FunctionCall) goja.Value {
+		parts := make([]string, len(call.Arguments))
+		for i, arg := range call.Arguments {
+			parts[i] = scriptValueToString(arg)
+		}
+		console.printf("%s", strings.Join(parts, " "))
+		return goja.Undefined()
+	}
+	consoleObj.Set("log", logFunc)
+	consoleObj.Set("info", logFunc)
+	consoleObj.Set("warn", logFunc)
+	consoleObj.Set("error", logFunc)
+	vm.Set("console", consoleObj)
+
+	envObj := vm.NewObject()
+	envObj.Set("get", func(name string) goja.Value {
+		if v, ok := sv.vars[name]; ok {
+			return vm.ToValue(v)
+		}
+		return goja.Undefined()
+	})
+	envObj.Set("set", func(name string, value goja.Value) {
+		sv.setVar(name, scriptValueToString(value))
+	})
+	envObj.Set("unset", sv.unsetVar)
+	envObj.Set("replace", func(text string) string {
+		return substituteVariables(text, sv.vars)
+	})
+	vm.Set("env", envObj)
+
+	cryptoObj := vm.NewObject()
+	cryptoObj.Set("md5", func(s string) string { return hashHex(md5.New(), s) })
+	cryptoObj.Set("sha1", func(s string) string { return hashHex(sha1.New(), s) })
+	cryptoObj.Set("sha256", func(s string) string { return hashHex(sha256.New(), s) })
+	cryptoObj.Set("sha512", func(s string) string { return hashHex(sha512.New(), s) })
+	cryptoObj.Set("hmacSHA256", func(key, s string) string { return hashHex(hmac.New(sha256.New, []byte(key)), s) })
+	cryptoObj.Set("hmacSHA512", func(key, s string) string { return hashHex(hmac.New(sha512.New, []byte(key)), s) })
+	cryptoObj.Set("base64Encode", func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) })
+	cryptoObj.Set("base64Decode", func(s string) (string, error) {
+		data, err := base64.StdEncoding.DecodeString(s)
+		return string(data), err
+	})
+	cryptoObj.Set("randomHex", func(n int) string {
+		if n < 1 || n > maxRandomHexBytes {
+			panic(vm.NewGoError(fmt.Errorf("randomHex: length must be between 1 and %d bytes", maxRandomHexBytes)))
+		}
+		buf := make([]byte, n)
+		rand.Read(buf)
+		return hex.EncodeToString(buf)
+	})
+	cryptoObj.Set("uuid", func() string { return newUUID() })
+	vm.Set("crypto", cryptoObj)
+
+	httpObj := vm.NewObject()
+	httpObj.Set("request", func(opts map[string]interface{}) *goja.Object {
+		return scriptHttpRequest(vm, opts)
+	})
+	vm.Set("http", httpObj)
+
+	// The script timeout cannot interrupt a Go call, so sleep never runs past it.
+	// Batas waktu script tidak dapat menghentikan pemanggilan Go, sehingga sleep tidak pernah melewatinya.
+	deadline := time.Now().Add(scriptTimeout)
+	vm.Set("sleep", func(ms float64) {
+		if !(ms > 0) {
+			return
+		}
+		wait := time.Until(deadline)
+		if ms*float64(time.Millisecond) < float64(wait) {
+			wait = time.Duration(ms * float64(time.Millisecond))
+		}
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+	})
+	return vm
+}
+
+// scriptHttpRequest performs an HTTP request on behalf of a script, e.g. to poll a job until it completes.
+// scriptHttpRequest menjalankan request HTTP atas nama script, mis. untuk polling job sampai selesai.
+func scriptHttpRequest(vm *goja.Runtime, opts map[string]interface{}) *goja.Object {
+	data := HttpRequestData{Method: "GET", Headers: make(map[string]string)}
+	if v, ok := opts["method"].(string); ok && v != "" {
+		data.Method = strings.ToUpper(v)
+	}
+	if v, ok := opts["url"].(string); ok {
+		data.URL = v
+	}
+	if headers, ok := opts["headers"].(map[string]interface{}); ok {
+		for k, v := range headers {
+			data.Headers[k] = fmt.Sprint(v)
+		}
+	}
+	switch body := opts["body"].(type) {
+	case nil:
+	case string:
+		data.Body = body
+	default:
+		encoded, _ := json.Marshal(body)
+		data.Body = string(encoded)
+	}
+
+	resp := doHttpRequest(data)
+	if resp.Error != nil {
+		panic(vm.NewGoError(resp.Error))
+	}
+	return responseToScriptObject(vm, &responseSnapshot{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    resp.Headers,
+		Body:       resp.Body,
+		Duration:   resp.Duration,
+	})
+}
+
+// requestToScriptObject exposes a request to scripts as a plain JavaScript object.
+// requestToScriptObject mengekspos sebuah request ke script sebagai object JavaScript biasa.
+func requestToScriptObject(vm *goja.Runtime, req *Request) *goja.Object {
+	obj := vm.NewObject()
+	obj.Set("name", req.Name)
+	obj.Set("type", req.Type)
+	obj.Set("body", req.Body)
+	if req.Type == "grpc" {
+		obj.Set("server", req.GrpcServer)
+		obj.Set("method", req.GrpcMethod)
+		meta, err := parseGrpcMetadata(req.GrpcMetadata)
+		if err != nil {
+			meta = make(map[string]string)
+		}
+		obj.Set("metadata", stringMapToScript(vm, meta))
+		return obj
+	}
+
+	obj.Set("method", req.Method)
+	obj.Set("url", req.URL)
+	obj.Set("headers", stringMapToScript(vm, requestHeaders(req)))
+	return obj
+}
+
+// scriptObjectToRequest copies the fields a pre-request script may have changed back into req.
+// scriptObjectToRequest menyalin field yang mungkin diubah script pre-request kembali ke req.
+func scriptObjectToRequest(obj *goja.Object, req *Request) {
+	req.Body = scriptValueToString(obj.Get("body"))
+	if req.Type == "grpc" {
+		req.GrpcServer = scriptValueToString(obj.Get("server"))
+		req.GrpcMethod = scriptValueToString(obj.Get("method"))
+		if meta := scriptObjectToStringMap(obj.Get("metadata")); meta != nil {
+			encoded, _ := json.Marshal(meta)
+			req.GrpcMetadata = string(encoded)
+		}
+		return
+	}
+
+	req.Method = strings.ToUpper(scriptValueToString(obj.Get("method")))
+	req.URL = scriptValueToString(obj.Get("url"))
+	if headers := scriptObjectToStringMap(obj.Get("headers")); headers != nil {
+		req.Headers = headers
+		req.HeadersRaw = "" // The parsed map is now authoritative. / Map hasil parse sekarang menjadi acuan.
+	}
+}
+
+// responseToScriptObject exposes a response to scripts, including a json() helper.
+// responseToScriptObject mengekspos sebuah response ke script, termasuk helper json().
+func responseToScriptObject(vm *goja.Runtime, resp *responseSnapshot) *goja.Object {
+	obj := vm.NewObject()
+	obj.Set("status", resp.Status)
+	obj.Set("code", resp.StatusCode)
+	obj.Set("body", string(resp.Body))
+	obj.Set("duration", resp.Duration.Milliseconds())
+
+	headers := make(map[string]string, len(resp.Headers))
+	for k, v := range resp.Headers {
+		headers[k] = strings.Join(v, ", ")
+	}
+	obj.Set("headers", stringMapToScript(vm, headers))
+
+	body := resp.Body
+	obj.Set("json", func() goja.Value {
+		var parsed interface{}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			panic(vm.NewGoError(fmt.Errorf("response body is not valid JSON: %w", err)))
+		}
+		return vm.ToValue(parsed)
+	})
+	return obj
+}
+
+// requestHeaders returns the headers of a saved HTTP request, preferring the raw JSON text.
+// requestHeaders mengembalikan headers dari request HTTP tersimpan, dengan memprioritaskan teks JSON mentah.
+func requestHeaders(req *Request) map[string]string {
+	headers := make(map[string]string)
+	if strings.TrimSpace(req.HeadersRaw) != "" {
+		if err := json.Unmarshal([]byte(req.HeadersRaw), &headers); err == nil {
+			return headers
+		}
+	}
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+	return headers
+}
+
+// stringMapToScript converts a Go string map into a mutable JavaScript object.
+// stringMapToScript mengkonversi map string Go menjadi object JavaScript yang dapat diubah.
+func stringMapToScript(vm *goja.Runtime, m map[string]string) *goja.Object {
+	obj := vm.NewObject()
+	for k, v := range m {
+		obj.Set(k, v)
+	}
+	return obj
+}
+
+// scriptObjectToStringMap reads a JavaScript object back into a Go string map.
+// scriptObjectToStringMap membaca object JavaScript kembali menjadi map string Go.
+func scriptObjectToStringMap(v goja.Value) map[string]string {
+	obj, ok := v.(*goja.Object)
+	if !ok {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, key := range obj.Keys() {
+		m[key] = scriptValueToString(obj.Get(key))
+	}
+	return m
+}
+
+// scriptValueToString converts a JavaScript value to text; objects and arrays become JSON.
+// scriptValueToString mengkonversi nilai JavaScript menjadi teks; object dan array menjadi JSON.
+func scriptValueToString(v goja.Value) string {
+	if v == nil || goja.IsUndefined(v) || goja.IsNull(v) {
+		return ""
+	}
+	if _, isObject := v.(*goja.Object); isObject {
+		if encoded, err := json.Marshal(v.Export()); err == nil {
+			return string(encoded)
+		}
+	}
+	return v.String()
+}
+
+// hashHex writes s into h and returns the hex-encoded digest.
+// hashHex menulis s ke dalam h dan mengembalikan digest dalam bentuk hex.
+func hashHex(h hash.Hash, s string) string {
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newUUID returns a random (version 4) UUID.
+// newUUID mengembalikan UUID acak (versi 4).
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
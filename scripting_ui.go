@@ -0,0 +1,173 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// requestScripts holds the pre-request and post-response scripts of the request being edited.
+// requestScripts menyimpan script pre-request dan post-response dari request yang sedang diedit.
+type requestScripts struct {
+	pre  string
+	post string
+}
+
+// activeVariables returns a copy of the active environment's variables, including linked ones.
+// activeVariables mengembalikan salinan variabel dari environment yang aktif, termasuk yang ditautkan.
+func (a *App) activeVariables() map[string]string {
+	if len(a.environments) == 0 || a.activeEnvIndex >= len(a.environments) {
+		return make(map[string]string)
+	}
+	return a.environments[a.activeEnvIndex].allVariables()
+}
+
+// applyScriptResults persists variables changed by scripts and captures into the active
+// environment and appends script output to the console. /
+// applyScriptResults menyimpan variabel yang diubah oleh script dan capture ke environment
+// yang aktif serta menambahkan output script ke console.
+func (a *App) applyScriptResults(sv *scriptVariables, console *scriptConsole) {
+	a.appendConsole(console.lines)
+	if len(a.environments) == 0 || a.activeEnvIndex >= len(a.environments) {
+		return
+	}
+	env := a.environments[a.activeEnvIndex]
+	if env.Variables == nil {
+		env.Variables = make(map[string]string)
+	}
+	if sv.applyTo(env.Variables) {
+		a.saveEnvironments()
+	}
+}
+
+// appendConsole adds lines to the script console shown with F2.
+// appendConsole menambahkan baris ke script console yang ditampilkan dengan F2.
+func (a *App) appendConsole(lines []string) {
+	a.consoleLines = append(a.consoleLines, lines...)
+}
+
+// showScriptsModal edits the pre-request and post-response scripts of the current request.
+// showScriptsModal mengedit script pre-request dan post-response dari request saat ini.
+func (a *App) showScriptsModal() {
+	currentPage, _ := a.rootPages.GetFrontPage()
+	scripts := &a.httpScripts
+	if currentPage == "grpc" {
+		scripts = &a.grpcScripts
+	}
+
+	preText := tview.NewTextArea().
+		SetPlaceholder("Runs before the request is sent, e.g.\nenv.set(\"ts\", String(Date.now()));\nrequest.headers[\"X-Signature\"] = crypto.hmacSHA256(env.get(\"secret\"), request.body);")
+	preText.SetText(scripts.pre, false)
+	preText.SetBorder(true).SetTitle(" Pre-request Script ")
+
+	postText := tview.NewTextArea().
+		SetPlaceholder("Runs after the response is received, e.g.\nif (response.code !== 200) throw new Error(\"unexpected \" + response.status);\nenv.set(\"token\", response.json().data.token);")
+	postText.SetText(scripts.post, false)
+	postText.SetBorder(true).SetTitle(" Post-response Script ")
+
+	closeModal := func() {
+		a.rootPages.RemovePage("scriptsModal")
+	}
+	saveBtn := tview.NewButton("Save").SetSelectedFunc(func() {
+		scripts.pre = preText.GetText()
+		scripts.post = postText.GetText()
+		closeModal()
+	})
+	cancelBtn := tview.NewButton("Cancel").SetSelectedFunc(closeModal)
+
+	buttons := tview.NewFlex().
+		AddItem(tview.NewTextView().SetDynamicColors(true).SetText("[gray]Tab: switch field | Save with F8 to keep scripts in a collection"), 0, 1, false).
+		AddItem(saveBtn, 8, 0, false).
+		AddItem(cancelBtn, 10, 0, false)
+
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(preText, 0, 1, true).
+		AddItem(postText, 0, 1, false).
+		AddItem(buttons, 1, 0, false)
+	content.SetBorder(true).SetTitle(" Scripts ")
+
+	focusables := []tview.Primitive{preText, postText, saveBtn, cancelBtn}
+	content.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			for i, p := range focusables {
+				if p.HasFocus() {
+					step := 1
+					if event.Key() == tcell.KeyBacktab {
+						step = len(focusables) - 1
+					}
+					a.app.SetFocus(focusables[(i+step)%len(focusables)])
+					return nil
+				}
+			}
+		}
+		return event
+	})
+
+	modal := a.createModal(content, 90, 30)
+	a.rootPages.AddPage("scriptsModal", modal, true, true)
+	a.app.SetFocus(preText)
+}
+
+// showConsoleModal displays the output written by scripts through console.log.
+// showConsoleModal menampilkan output yang ditulis oleh script melalui console.log.
+func (a *App) showConsoleModal() {
+	consoleView := tview.NewTextView().
+		SetScrollable(true).
+		SetWrap(true)
+	consoleView.SetBorder(true).SetTitle(" Output ")
+
+	refresh := func() {
+		if len(a.consoleLines) == 0 {
+			consoleView.SetText("No script output yet.")
+			return
+		}
+		consoleView.SetText(strings.Join(a.consoleLines, "\n"))
+		consoleView.ScrollToEnd()
+	}
+	refresh()
+
+	closeModal := func() {
+		a.rootPages.RemovePage("consoleModal")
+	}
+	clearBtn := tview.NewButton("Clear").SetSelectedFunc(func() {
+		a.consoleLines = nil
+		refresh()
+	})
+	closeBtn := tview.NewButton("Close (Esc)").SetSelectedFunc(closeModal)
+
+	buttons := tview.NewFlex().
+		AddItem(tview.NewBox(), 0, 1, false).
+		AddItem(clearBtn, 9, 0, false).
+		AddItem(closeBtn, 15, 0, false)
+
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(consoleView, 0, 1, true).
+		AddItem(buttons, 1, 0, false)
+	content.SetBorder(true).SetTitle(" Script Console ")
+	content.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		case tcell.KeyTab:
+			if consoleView.HasFocus() {
+				a.app.SetFocus(clearBtn)
+			} else if clearBtn.HasFocus() {
+				a.app.SetFocus(closeBtn)
+			} else {
+				a.app.SetFocus(consoleView)
+			}
+			return nil
+		}
+		return event
+	})
+
+	modal := a.createModal(content, 90, 25)
+	a.rootPages.AddPage("consoleModal", modal, true, true)
+	a.app.SetFocus(consoleView)
+}
@@ -0,0 +1,633 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Secret values are stored as "secret:v1:local:<data>" when encrypted with the local key, or
+// "secret:v1:pass:<salt>:<data>" when encrypted with a key derived from PANGGIL_PASSPHRASE.
+// The local key lives in the OS keyring when one is available and in secret.key otherwise;
+// a passphrase lets a shared workspace be decrypted on other machines, such as CI runners. /
+// Nilai rahasia disimpan sebagai "secret:v1:local:<data>" jika dienkripsi dengan key lokal, atau
+// "secret:v1:pass:<salt>:<data>" jika dienkripsi dengan key yang diturunkan dari PANGGIL_PASSPHRASE.
+// Key lokal disimpan di keyring OS jika tersedia dan di secret.key jika tidak; passphrase
+// memungkinkan workspace bersama didekripsi di mesin lain, seperti runner CI.
+const (
+	secretPrefix        = "secret:v1:"
+	secretPassphraseEnv = "PANGGIL_PASSPHRASE"
+	secretKeyringName   = "panggil"
+	secretKeyringItem   = "secret-key"
+	secretKDFIterations = 600000
+	secretMask          = "********"
+)
+
+// secretKeys caches the keys used to encrypt and decrypt secret values.
+// secretKeys menyimpan cache key yang dipakai untuk mengenkripsi dan mendekripsi nilai rahasia.
+var secretKeys = struct {
+	sync.Mutex
+	local    []byte
+	localErr error             // Why the keyring could not be read, so it is not asked for every value / Alasan keyring tidak dapat dibaca, agar tidak ditanya untuk setiap nilai
+	derived  map[string][]byte // Passphrase keys by salt / Key passphrase berdasarkan salt
+	salt     []byte            // Salt for values encrypted by this process / Salt untuk nilai yang dienkripsi proses ini
+	sealed   map[string]string // Plaintext to the stored ciphertext, so unchanged secrets keep their bytes / Plaintext ke ciphertext tersimpan, agar rahasia yang tidak berubah tetap sama
+}{derived: make(map[string][]byte), sealed: make(map[string]string)}
+
+// undecryptableSecrets describes the stored secrets that could not be decrypted when loaded,
+// by their encrypted value. /
+// undecryptableSecrets menjelaskan rahasia tersimpan yang tidak dapat didekripsi saat dimuat,
+// berdasarkan nilai terenkripsinya.
+var undecryptableSecrets = struct {
+	sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// markUndecryptable records that the secret value stored at where could not be decrypted.
+// markUndecryptable mencatat bahwa nilai rahasia yang tersimpan di where tidak dapat didekripsi.
+func markUndecryptable(value, where string, err error) {
+	log.Printf("ERROR: %s could not be decrypted, requests using it will not be sent: %v", where, err)
+	undecryptableSecrets.Lock()
+	defer undecryptableSecrets.Unlock()
+	undecryptableSecrets.values[value] = fmt.Sprintf("%s could not be decrypted: %v", where, err)
+}
+
+// undecryptableCount returns how many stored secrets could not be decrypted.
+// undecryptableCount mengembalikan jumlah rahasia tersimpan yang tidak dapat didekripsi.
+func undecryptableCount() int {
+	undecryptableSecrets.Lock()
+	defer undecryptableSecrets.Unlock()
+	return len(undecryptableSecrets.values)
+}
+
+// checkSecretsDecrypted returns an error when one of texts still holds an encrypted secret, so
+// the ciphertext is never sent as if it were the real value. /
+// checkSecretsDecrypted mengembalikan error jika salah satu texts masih berisi rahasia
+// terenkripsi, sehingga ciphertext tidak pernah dikirim seolah-olah nilai aslinya.
+func checkSecretsDecrypted(texts ...string) error {
+	undecryptableSecrets.Lock()
+	defer undecryptableSecrets.Unlock()
+	for _, text := range texts {
+		if !strings.Contains(text, secretPrefix) {
+			continue
+		}
+		for value, msg := range undecryptableSecrets.values {
+			if strings.Contains(text, value) {
+				return errors.New(msg)
+			}
+		}
+		return errors.New("the request contains an encrypted secret that could not be decrypted")
+	}
+	return nil
+}
+
+// isEncryptedSecret reports whether value is an encrypted secret as stored on disk.
+// isEncryptedSecret melaporkan apakah value adalah rahasia terenkripsi seperti yang disimpan di disk.
+func isEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, secretPrefix)
+}
+
+// encryptSecret encrypts value for storage. A value that was decrypted earlier in this process
+// gets its original ciphertext back, so saving unchanged secrets does not rewrite files. /
+// encryptSecret mengenkripsi value untuk disimpan. Nilai yang sebelumnya didekripsi di proses ini
+// mendapatkan kembali ciphertext aslinya, sehingga menyimpan rahasia yang tidak berubah tidak menulis ulang file.
+func encryptSecret(value string) (string, error) {
+	if value == "" || isEncryptedSecret(value) {
+		return value, nil
+	}
+	secretKeys.Lock()
+	defer secretKeys.Unlock()
+	passphrase := os.Getenv(secretPassphraseEnv)
+	mode := "local:"
+	if passphrase != "" {
+		mode = "pass:"
+	}
+	if sealed, ok := secretKeys.sealed[value]; ok && strings.HasPrefix(sealed, secretPrefix+mode) {
+		return sealed, nil
+	}
+
+	var key []byte
+	var header string
+	if passphrase != "" {
+		if secretKeys.salt == nil {
+			secretKeys.salt = make([]byte, 16)
+			rand.Read(secretKeys.salt)
+		}
+		salt := base64.RawStdEncoding.EncodeToString(secretKeys.salt)
+		var err error
+		if key, err = derivedSecretKey(passphrase, salt); err != nil {
+			return "", err
+		}
+		header = secretPrefix + "pass:" + salt + ":"
+	} else {
+		var err error
+		if key, err = localSecretKey(); err != nil {
+			return "", err
+		}
+		header = secretPrefix + "local:"
+	}
+
+	data, err := sealSecret(key, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	sealed := header + base64.RawStdEncoding.EncodeToString(data)
+	secretKeys.sealed[value] = sealed
+	return sealed, nil
+}
+
+// decryptSecret returns the plaintext of a value written by encryptSecret. Values that are not
+// encrypted are returned unchanged. /
+// decryptSecret mengembalikan plaintext dari nilai yang ditulis oleh encryptSecret. Nilai yang
+// tidak terenkripsi dikembalikan apa adanya.
+func decryptSecret(value string) (string, error) {
+	if !isEncryptedSecret(value) {
+		return value, nil
+	}
+	secretKeys.Lock()
+	defer secretKeys.Unlock()
+
+	var key []byte
+	var encoded string
+	rest := strings.TrimPrefix(value, secretPrefix)
+	switch {
+	case strings.HasPrefix(rest, "local:"):
+		var err error
+		if key, err = localSecretKey(); err != nil {
+			return "", err
+		}
+		encoded = strings.TrimPrefix(rest, "local:")
+	case strings.HasPrefix(rest, "pass:"):
+		salt, data, ok := strings.Cut(strings.TrimPrefix(rest, "pass:"), ":")
+		if !ok {
+			return "", fmt.Errorf("malformed secret")
+		}
+		passphrase := os.Getenv(secretPassphraseEnv)
+		if passphrase == "" {
+			return "", fmt.Errorf("secret was encrypted with a passphrase; set %s", secretPassphraseEnv)
+		}
+		var err error
+		if key, err = derivedSecretKey(passphrase, salt); err != nil {
+			return "", err
+		}
+		encoded = data
+	default:
+		return "", fmt.Errorf("unknown secret format")
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed secret: %w", err)
+	}
+	plain, err := openSecret(key, data)
+	if err != nil {
+		return "", fmt.Errorf("cannot decrypt secret (wrong key or passphrase)")
+	}
+	secretKeys.sealed[string(plain)] = value
+	return string(plain), nil
+}
+
+// sealSecret encrypts plain with AES-256-GCM and prepends the random nonce.
+// sealSecret mengenkripsi plain dengan AES-256-GCM dan menambahkan nonce acak di depannya.
+func sealSecret(key, plain []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+// openSecret decrypts data written by sealSecret.
+// openSecret mendekripsi data yang ditulis oleh sealSecret.
+func openSecret(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("secret too short")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+// derivedSecretKey derives a key from passphrase and an encoded salt with PBKDF2-SHA256.
+// The caller holds secretKeys' lock. /
+// derivedSecretKey menurunkan key dari passphrase dan salt yang ter-encode dengan PBKDF2-SHA256.
+// Pemanggil memegang lock secretKeys.
+func derivedSecretKey(passphrase, salt string) ([]byte, error) {
+	if key, ok := secretKeys.derived[salt]; ok {
+		return key, nil
+	}
+	rawSalt, err := base64.RawStdEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed secret salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, rawSalt, secretKDFIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	secretKeys.derived[salt] = key
+	return key, nil
+}
+
+// localSecretKey returns the key for secrets encrypted without a passphrase, creating it on
+// first use. It is kept in the OS keyring when available and in secret.key (mode 0600) in the
+// user config directory otherwise. When the keyring is installed but cannot be read, no key is
+// created, since one may already be stored there. The caller holds secretKeys' lock. /
+// localSecretKey mengembalikan key untuk rahasia yang dienkripsi tanpa passphrase, dan membuatnya
+// saat pertama kali dipakai. Key disimpan di keyring OS jika tersedia dan di secret.key (mode 0600)
+// di direktori config pengguna jika tidak. Jika keyring terpasang tetapi tidak dapat dibaca, key
+// tidak dibuat, karena mungkin sudah ada key yang tersimpan di sana. Pemanggil memegang lock secretKeys.
+func localSecretKey() ([]byte, error) {
+	if secretKeys.local != nil {
+		return secretKeys.local, nil
+	}
+	if secretKeys.localErr != nil {
+		return nil, secretKeys.localErr
+	}
+	path, err := getConfigPath("secret.key")
+	if err != nil {
+		return nil, err
+	}
+
+	source := "the OS keyring"
+	encoded, ok, keyringErr := keyringGet()
+	if !ok {
+		source = path
+		if data, err := os.ReadFile(path); err == nil {
+			encoded = string(bytes.TrimSpace(data))
+		}
+	}
+	// Creating a key while the keyring is unreadable would silently orphan every secret
+	// encrypted with the key it holds.
+	// Membuat key saat keyring tidak dapat dibaca akan membuat semua rahasia yang dienkripsi
+	// dengan key di dalamnya tidak dapat dibaca lagi tanpa pemberitahuan.
+	if encoded == "" && keyringErr != nil {
+		log.Printf("ERROR: Could not read the secret key from the OS keyring: %v", keyringErr)
+		secretKeys.localErr = fmt.Errorf("could not read the secret key from the OS keyring: %w", keyringErr)
+		return nil, secretKeys.localErr
+	}
+	if encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("invalid secret key in %s", source)
+		}
+		secretKeys.local = key
+		return key, nil
+	}
+
+	key := make([]byte, 32)
+	rand.Read(key)
+	encoded = base64.StdEncoding.EncodeToString(key)
+	if keyringSet(encoded) {
+		log.Printf("INFO: Created secret key in the OS keyring")
+	} else {
+		if err := os.WriteFile(path, []byte(encoded+"\n"), 0600); err != nil {
+			return nil, fmt.Errorf("could not store secret key: %w", err)
+		}
+		log.Printf("INFO: Created secret key in %s", path)
+	}
+	secretKeys.local = key
+	return key, nil
+}
+
+// keyringGet reads the local key from the OS keyring through the platform's command-line tool:
+// `security` on macOS and `secret-tool` (libsecret) on Linux. It reports an error only when the
+// tool is installed but the lookup failed, e.g. on a timeout or a locked keyring, and not when
+// there is no tool or no key. /
+// keyringGet membaca key lokal dari keyring OS melalui tool command-line platform:
+// `security` di macOS dan `secret-tool` (libsecret) di Linux. Error hanya dilaporkan jika tool
+// terpasang tetapi pencarian gagal, mis. karena timeout atau keyring terkunci, dan tidak jika
+// tool atau key-nya tidak ada.
+func keyringGet() (string, bool, error) {
+	var cmd []string
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = []string{"security", "find-generic-password", "-s", secretKeyringName, "-a", secretKeyringItem, "-w"}
+	case "linux":
+		cmd = []string{"secret-tool", "lookup", "service", secretKeyringName, "account", secretKeyringItem}
+	default:
+		return "", false, nil
+	}
+	out, err := runKeyringTool(cmd, "")
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(err, exec.ErrNotFound):
+		return "", false, nil
+	case errors.As(err, &exitErr):
+		// `security` exits with 44 for a missing item; `secret-tool` exits with 1 and prints
+		// nothing.
+		// `security` keluar dengan 44 untuk item yang tidak ada; `secret-tool` keluar dengan 1
+		// dan tidak mencetak apa pun.
+		msg := strings.TrimSpace(string(exitErr.Stderr))
+		if (runtime.GOOS == "darwin" && exitErr.ExitCode() == 44) || (runtime.GOOS == "linux" && exitErr.ExitCode() == 1 && msg == "") {
+			return "", false, nil
+		}
+		if msg != "" {
+			return "", false, fmt.Errorf("%v: %s", err, msg)
+		}
+		return "", false, err
+	case err != nil:
+		return "", false, err
+	}
+	if strings.TrimSpace(out) == "" {
+		return "", false, nil
+	}
+	return strings.TrimSpace(out), true, nil
+}
+
+// keyringSet stores the local key in the OS keyring and reports whether that worked.
+// keyringSet menyimpan key lokal di keyring OS dan melaporkan apakah berhasil.
+func keyringSet(encoded string) bool {
+	var cmd []string
+	input := ""
+	switch runtime.GOOS {
+	case "darwin":
+		// The command is read from stdin by `security -i`, so the key never appears in the
+		// argument list that other local users can see with ps.
+		// Perintah dibaca dari stdin oleh `security -i`, sehingga key tidak pernah muncul di
+		// daftar argumen yang dapat dilihat pengguna lokal lain dengan ps.
+		cmd = []string{"security", "-i"}
+		input = fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", secretKeyringName, secretKeyringItem, encoded)
+	case "linux":
+		cmd = []string{"secret-tool", "store", "--label=panggil secret key", "service", secretKeyringName, "account", secretKeyringItem}
+		input = encoded
+	default:
+		return false
+	}
+	if _, err := runKeyringTool(cmd, input); err != nil {
+		return false
+	}
+	// `security -i` does not fail when the command it reads fails, so read the key back.
+	// `security -i` tidak gagal jika perintah yang dibacanya gagal, sehingga key dibaca kembali.
+	stored, ok, _ := keyringGet()
+	return ok && stored == encoded
+}
+
+// runKeyringTool runs a keyring command with a timeout, since an unavailable keyring daemon can
+// otherwise hang. It fails right away when the tool is not installed. /
+// runKeyringTool menjalankan perintah keyring dengan batas waktu, karena daemon keyring yang tidak
+// tersedia dapat membuatnya menggantung. Langsung gagal jika tool tidak terpasang.
+func runKeyringTool(args []string, input string) (string, error) {
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%s timed out", args[0])
+	}
+	return string(out), err
+}
+
+// isSecret reports whether the variable name of the environment is marked secret.
+// isSecret melaporkan apakah variabel name dari environment ditandai sebagai rahasia.
+func (e *Environment) isSecret(name string) bool {
+	for _, s := range e.Secrets {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setSecret marks or unmarks the variable name as secret.
+// setSecret menandai atau menghapus tanda rahasia dari variabel name.
+func (e *Environment) setSecret(name string, secret bool) {
+	var names []string
+	for _, s := range e.Secrets {
+		if s != name {
+			names = append(names, s)
+		}
+	}
+	if secret {
+		names = append(names, name)
+		sort.Strings(names)
+	}
+	e.Secrets = names
+}
+
+// sealEnvironments returns copies of envs with secret variable values encrypted for saving.
+// sealEnvironments mengembalikan salinan envs dengan nilai variabel rahasia terenkripsi untuk disimpan.
+func sealEnvironments(envs []*Environment) ([]*Environment, error) {
+	sealed := make([]*Environment, len(envs))
+	for i, env := range envs {
+		copied := *env
+		if len(env.Secrets) > 0 {
+			copied.Variables = make(map[string]string, len(env.Variables))
+			for k, v := range env.Variables {
+				if env.isSecret(k) {
+					var err error
+					if v, err = encryptSecret(v); err != nil {
+						return nil, fmt.Errorf("encrypting %s/%s: %w", env.Name, k, err)
+					}
+				}
+				copied.Variables[k] = v
+			}
+		}
+		sealed[i] = &copied
+	}
+	return sealed, nil
+}
+
+// openEnvironmentSecrets decrypts the secret variables of envs in place. A value that cannot
+// be decrypted is kept encrypted, so it is saved back unchanged, and marked so requests using
+// it fail instead of sending the ciphertext. /
+// openEnvironmentSecrets mendekripsi variabel rahasia dari envs secara langsung. Nilai yang tidak
+// dapat didekripsi tetap terenkripsi, sehingga disimpan kembali tanpa perubahan, dan ditandai
+// agar request yang memakainya gagal alih-alih mengirim ciphertext.
+func openEnvironmentSecrets(envs []*Environment) {
+	for _, env := range envs {
+		for k, v := range env.Variables {
+			if !isEncryptedSecret(v) {
+				continue
+			}
+			plain, err := decryptSecret(v)
+			if err != nil {
+				markUndecryptable(v, fmt.Sprintf("secret variable '%s' of environment '%s'", k, env.Name), err)
+				continue
+			}
+			env.Variables[k] = plain
+		}
+	}
+}
+
+// sealCollection returns a copy of node with the auth credentials of secret requests encrypted.
+// sealCollection mengembalikan salinan node dengan kredensial auth dari request rahasia terenkripsi.
+func sealCollection(node *CollectionNode) (*CollectionNode, error) {
+	copied := *node
+	if node.Request != nil && node.Request.AuthSecret {
+		req := *node.Request
+		var err error
+		if req.AuthToken, err = encryptSecret(req.AuthToken); err != nil {
+			return nil, fmt.Errorf("encrypting '%s': %w", node.Name, err)
+		}
+		if req.AuthPass, err = encryptSecret(req.AuthPass); err != nil {
+			return nil, fmt.Errorf("encrypting '%s': %w", node.Name, err)
+		}
+		copied.Request = &req
+	}
+	copied.Children = make([]*CollectionNode, len(node.Children))
+	for i, child := range node.Children {
+		var err error
+		if copied.Children[i], err = sealCollection(child); err != nil {
+			return nil, err
+		}
+	}
+	return &copied, nil
+}
+
+// openCollectionSecrets decrypts the auth credentials of secret requests under node in place,
+// marking the ones that cannot be decrypted like openEnvironmentSecrets. /
+// openCollectionSecrets mendekripsi kredensial auth dari request rahasia di bawah node secara
+// langsung, dan menandai yang tidak dapat didekripsi seperti openEnvironmentSecrets.
+func openCollectionSecrets(node *CollectionNode) {
+	if req := node.Request; req != nil {
+		for _, field := range []*string{&req.AuthToken, &req.AuthPass} {
+			if plain, err := decryptSecret(*field); err != nil {
+				markUndecryptable(*field, fmt.Sprintf("auth credential of request '%s'", node.Name), err)
+			} else {
+				*field = plain
+			}
+		}
+	}
+	for _, child := range node.Children {
+		openCollectionSecrets(child)
+	}
+}
+
+// redactCollection returns a copy of node without the auth credentials of secret requests, for exports.
+// redactCollection mengembalikan salinan node tanpa kredensial auth dari request rahasia, untuk export.
+func redactCollection(node *CollectionNode) *CollectionNode {
+	copied := *node
+	if node.Request != nil && node.Request.AuthSecret {
+		req := *node.Request
+		req.AuthToken, req.AuthPass = "", ""
+		copied.Request = &req
+	}
+	copied.Children = make([]*CollectionNode, len(node.Children))
+	for i, child := range node.Children {
+		copied.Children[i] = redactCollection(child)
+	}
+	return &copied
+}
+
+// secretRedactor replaces the values of secret variables from every environment and the output
+// of command variables with a mask, longest first, for text leaving panggil such as HAR exports. /
+// secretRedactor mengganti nilai variabel rahasia dari semua environment dan output variabel
+// perintah dengan mask, mulai dari yang terpanjang, untuk teks yang keluar dari panggil seperti
+// export HAR.
+func (a *App) secretRedactor() *strings.Replacer {
+	var values []string
+	for _, env := range a.environments {
+		for _, name := range env.Secrets {
+			if v := env.Variables[name]; v != "" && !isEncryptedSecret(v) {
+				values = append(values, v)
+			}
+		}
+	}
+	values = append(values, commandOutputs()...)
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	var pairs []string
+	for _, v := range values {
+		pairs = append(pairs, v, secretMask)
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// redactHistory returns copies of history entries with secret values masked, for exports.
+// redactHistory mengembalikan salinan entri History dengan nilai rahasia disamarkan, untuk export.
+func (a *App) redactHistory(entries []Request) []Request {
+	redactor := a.secretRedactor()
+	redacted := make([]Request, len(entries))
+	for i, entry := range entries {
+		entry.URL = redactor.Replace(entry.URL)
+		entry.Body = redactor.Replace(entry.Body)
+		headers := make(map[string]string, len(entry.Headers))
+		for k, v := range entry.Headers {
+			headers[k] = redactor.Replace(v)
+		}
+		entry.Headers = headers
+		for _, field := range []*string{&entry.AuthToken, &entry.AuthPass} {
+			if entry.AuthSecret && *field != "" {
+				*field = secretMask
+			} else {
+				*field = redactor.Replace(*field)
+			}
+		}
+		redacted[i] = entry
+	}
+	return redacted
+}
+
+// maskSecrets replaces the values of the active environment's secret variables in vars with a
+// mask unless secrets are revealed. vars is modified in place. /
+// maskSecrets mengganti nilai variabel rahasia dari environment aktif di vars dengan mask kecuali
+// rahasia sedang ditampilkan. vars diubah secara langsung.
+func (a *App) maskSecrets(vars map[string]string) map[string]string {
+	if a.revealSecrets || len(a.environments) == 0 || a.activeEnvIndex >= len(a.environments) {
+		return vars
+	}
+	env := a.environments[a.activeEnvIndex]
+	for _, name := range env.Secrets {
+		if v, ok := vars[name]; ok && v == env.Variables[name] {
+			vars[name] = secretMask
+		}
+	}
+	return vars
+}
+
+// maskedAuthValue returns value, or a mask when the auth fields of the HTTP view are secret and not revealed.
+// maskedAuthValue mengembalikan value, atau mask jika field auth di view HTTP bersifat rahasia dan tidak ditampilkan.
+func (a *App) maskedAuthValue(value string) string {
+	if value != "" && a.authSecret.IsChecked() && !a.revealSecrets {
+		return secretMask
+	}
+	return value
+}
+
+// applySecretMasks hides or shows the auth token and password fields.
+// applySecretMasks menyembunyikan atau menampilkan field token dan password auth.
+func (a *App) applySecretMasks() {
+	var mask rune
+	if a.authSecret.IsChecked() && !a.revealSecrets {
+		mask = '*'
+	}
+	a.authToken.SetMaskCharacter(mask)
+	a.authPass.SetMaskCharacter(mask)
+}
+
+// toggleRevealSecrets shows or hides secret values throughout the UI.
+// toggleRevealSecrets menampilkan atau menyembunyikan nilai rahasia di seluruh UI.
+func (a *App) toggleRevealSecrets() {
+	a.revealSecrets = !a.revealSecrets
+	a.applySecretMasks()
+}
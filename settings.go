@@ -0,0 +1,256 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// Settings are application-wide preferences stored in settings.json in the user config
+// directory. They apply to every workspace. /
+// Settings adalah preferensi seluruh aplikasi yang disimpan di settings.json di direktori config
+// pengguna. Berlaku untuk semua workspace.
+type Settings struct {
+	HTTPTimeoutSeconds     int               `json:"http_timeout_seconds"`
+	GrpcDialTimeoutSeconds int               `json:"grpc_dial_timeout_seconds"`
+	GrpcCallTimeoutSeconds int               `json:"grpc_call_timeout_seconds"`
+	DefaultGrpcServer      string            `json:"default_grpc_server"`
+	ExplorerWidth          int               `json:"explorer_width"`
+	ShowExplorer           bool              `json:"show_explorer"`             // Show the explorer panel at startup / Tampilkan explorer panel saat startup
+	DefaultHeaders         map[string]string `json:"default_headers,omitempty"` // Sent with every HTTP request that does not set them / Dikirim di setiap request HTTP yang tidak men-set-nya
+	HistorySize            int               `json:"history_size"`              // 0 keeps every entry / 0 menyimpan semua entri
+	JSONIndent             int               `json:"json_indent"`               // Spaces, or 0 for a tab / Spasi, atau 0 untuk tab
+	WrapLines              bool              `json:"wrap_lines"`                // Soft-wrap long lines in the editors / Bungkus baris panjang di editor
+	FormatResponses        bool              `json:"format_responses"`          // Pretty-print JSON responses / Format response JSON agar mudah dibaca
+}
+
+// settings holds the preferences in effect, loaded once at startup by loadSettings.
+// settings menyimpan preferensi yang berlaku, dimuat sekali saat startup oleh loadSettings.
+var settings = defaultSettings()
+
+// defaultSettings returns the preferences used when settings.json does not set them.
+// defaultSettings mengembalikan preferensi yang dipakai jika settings.json tidak men-set-nya.
+func defaultSettings() Settings {
+	return Settings{
+		HTTPTimeoutSeconds:     30,
+		GrpcDialTimeoutSeconds: 10,
+		GrpcCallTimeoutSeconds: 30,
+		DefaultGrpcServer:      "localhost:8081",
+		ExplorerWidth:          40,
+		ShowExplorer:           false,
+		HistorySize:            0,
+		JSONIndent:             2,
+		WrapLines:              true,
+		FormatResponses:        true,
+	}
+}
+
+// validate reports the first preference that is out of range.
+// validate melaporkan preferensi pertama yang berada di luar rentang.
+func (s Settings) validate() error {
+	switch {
+	case s.HTTPTimeoutSeconds < 1:
+		return errors.New("HTTP timeout must be at least 1 second")
+	case s.GrpcDialTimeoutSeconds < 1:
+		return errors.New("gRPC dial timeout must be at least 1 second")
+	case s.GrpcCallTimeoutSeconds < 1:
+		return errors.New("gRPC call timeout must be at least 1 second")
+	case strings.TrimSpace(s.DefaultGrpcServer) == "":
+		return errors.New("default gRPC server is required")
+	case s.ExplorerWidth < 20 || s.ExplorerWidth > 120:
+		return errors.New("explorer width must be between 20 and 120")
+	case s.HistorySize < 0:
+		return errors.New("history size cannot be negative")
+	case s.JSONIndent < 0 || s.JSONIndent > 8:
+		return errors.New("JSON indent must be between 0 (tab) and 8")
+	}
+	return nil
+}
+
+// httpTimeout limits an HTTP request, including reading the response body.
+// httpTimeout membatasi sebuah request HTTP, termasuk membaca body response.
+func (s Settings) httpTimeout() time.Duration {
+	return time.Duration(s.HTTPTimeoutSeconds) * time.Second
+}
+
+// grpcDialTimeout limits connecting to a gRPC server.
+// grpcDialTimeout membatasi koneksi ke server gRPC.
+func (s Settings) grpcDialTimeout() time.Duration {
+	return time.Duration(s.GrpcDialTimeoutSeconds) * time.Second
+}
+
+// grpcCallTimeout limits a single gRPC call.
+// grpcCallTimeout membatasi satu panggilan gRPC.
+func (s Settings) grpcCallTimeout() time.Duration {
+	return time.Duration(s.GrpcCallTimeoutSeconds) * time.Second
+}
+
+// jsonIndent returns the indentation used when formatting JSON in the editors.
+// jsonIndent mengembalikan indentasi yang dipakai saat memformat JSON di editor.
+func (s Settings) jsonIndent() string {
+	if s.JSONIndent == 0 {
+		return "\t"
+	}
+	return strings.Repeat(" ", s.JSONIndent)
+}
+
+// loadSettings reads settings.json over the defaults, so a file only needs the preferences it
+// changes. A file that cannot be parsed or holds invalid values is ignored and not overwritten. /
+// loadSettings membaca settings.json di atas nilai default, sehingga file hanya perlu berisi
+// preferensi yang diubah. File yang gagal di-parse atau berisi nilai tidak valid diabaikan dan
+// tidak ditimpa.
+func loadSettings() {
+	path, err := getConfigPath("settings.json")
+	if err != nil {
+		log.Printf("ERROR: Could not get config path for settings: %v", err)
+		return
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	loaded := defaultSettings()
+	if err == nil {
+		err = json.Unmarshal(data, &loaded)
+	}
+	if err == nil {
+		err = loaded.validate()
+	}
+	if err != nil {
+		markLoadFailed(path, err)
+		return
+	}
+	markLoaded(path)
+	settings = loaded
+	log.Printf("INFO: Loaded settings from %s", path)
+}
+
+// saveSettings writes s to settings.json.
+// saveSettings menulis s ke settings.json.
+func saveSettings(s Settings) error {
+	path, err := getConfigPath("settings.json")
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return saveFile(path, append(data, '\n'), 0644)
+}
+
+// applySettings updates the open views after the preferences changed. The default gRPC server
+// is only used for new sessions, so the server field is left as it is. /
+// applySettings memperbarui view yang terbuka setelah preferensi berubah. Server gRPC default
+// hanya dipakai untuk sesi baru, sehingga field server dibiarkan apa adanya.
+func (a *App) applySettings() {
+	if a.explorerPanelVisible {
+		a.contentLayout.ResizeItem(a.explorerPanel, settings.ExplorerWidth, 0)
+	}
+	for _, editor := range []*tview.TextArea{a.headersText, a.bodyText, a.responseText, a.grpcRequestMeta, a.grpcRequestBody, a.grpcResponseView} {
+		editor.SetWrap(settings.WrapLines)
+	}
+	if settings.HistorySize > 0 && len(a.history) > settings.HistorySize {
+		a.history = a.history[:settings.HistorySize]
+		a.updateHistoryView()
+	}
+}
+
+// showSettingsModal edits the application-wide preferences and saves them to settings.json.
+// showSettingsModal mengedit preferensi seluruh aplikasi dan menyimpannya ke settings.json.
+func (a *App) showSettingsModal() {
+	returnFocus := a.app.GetFocus()
+	s := settings
+	numberInput := func(label string, value int) *tview.InputField {
+		return tview.NewInputField().SetLabel(label).SetText(strconv.Itoa(value)).
+			SetFieldWidth(8).SetAcceptanceFunc(tview.InputFieldInteger)
+	}
+	httpTimeout := numberInput("HTTP timeout (s)", s.HTTPTimeoutSeconds)
+	dialTimeout := numberInput("gRPC dial timeout (s)", s.GrpcDialTimeoutSeconds)
+	callTimeout := numberInput("gRPC call timeout (s)", s.GrpcCallTimeoutSeconds)
+	grpcServer := tview.NewInputField().SetLabel("Default gRPC server").SetText(s.DefaultGrpcServer).SetFieldWidth(30)
+	explorerWidth := numberInput("Explorer width", s.ExplorerWidth)
+	showExplorer := tview.NewCheckbox().SetLabel("Show explorer at startup").SetChecked(s.ShowExplorer)
+	headers := ""
+	if len(s.DefaultHeaders) > 0 {
+		raw, _ := json.Marshal(s.DefaultHeaders)
+		headers = string(raw)
+	}
+	headersInput := tview.NewInputField().SetLabel("Default headers").SetText(headers).SetFieldWidth(40).
+		SetPlaceholder(`{"User-Agent": "panggil"}`)
+	historySize := numberInput("History size (0 = all)", s.HistorySize)
+	jsonIndent := numberInput("JSON indent (0 = tab)", s.JSONIndent)
+	wrapLines := tview.NewCheckbox().SetLabel("Wrap long lines").SetChecked(s.WrapLines)
+	formatResponses := tview.NewCheckbox().SetLabel("Format JSON responses").SetChecked(s.FormatResponses)
+
+	closeModal := func() {
+		a.rootPages.RemovePage("settingsModal")
+		a.app.SetFocus(returnFocus)
+	}
+	number := func(input *tview.InputField) int {
+		n, _ := strconv.Atoi(input.GetText())
+		return n
+	}
+
+	var form *tview.Form
+	form = tview.NewForm().
+		AddFormItem(httpTimeout).
+		AddFormItem(dialTimeout).
+		AddFormItem(callTimeout).
+		AddFormItem(grpcServer).
+		AddFormItem(explorerWidth).
+		AddFormItem(showExplorer).
+		AddFormItem(headersInput).
+		AddFormItem(historySize).
+		AddFormItem(jsonIndent).
+		AddFormItem(wrapLines).
+		AddFormItem(formatResponses).
+		AddButton("Save", func() {
+			updated := Settings{
+				HTTPTimeoutSeconds:     number(httpTimeout),
+				GrpcDialTimeoutSeconds: number(dialTimeout),
+				GrpcCallTimeoutSeconds: number(callTimeout),
+				DefaultGrpcServer:      strings.TrimSpace(grpcServer.GetText()),
+				ExplorerWidth:          number(explorerWidth),
+				ShowExplorer:           showExplorer.IsChecked(),
+				HistorySize:            number(historySize),
+				JSONIndent:             number(jsonIndent),
+				WrapLines:              wrapLines.IsChecked(),
+				FormatResponses:        formatResponses.IsChecked(),
+			}
+			if text := strings.TrimSpace(headersInput.GetText()); text != "" {
+				if err := json.Unmarshal([]byte(text), &updated.DefaultHeaders); err != nil {
+					form.SetTitle(" [red]default headers must be a JSON object of strings ")
+					return
+				}
+			}
+			if err := updated.validate(); err != nil {
+				form.SetTitle(fmt.Sprintf(" [red]%s ", err))
+				return
+			}
+			if err := saveSettings(updated); err != nil {
+				log.Printf("ERROR: Failed to write settings file: %v", err)
+				form.SetTitle(" [red]could not save settings (see log) ")
+				return
+			}
+			settings = updated
+			a.applySettings()
+			closeModal()
+			a.statusText.SetText("[green]Settings saved")
+		}).
+		AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+	form.SetBorder(true).SetTitle(" Settings ")
+
+	modal := a.createModal(form, 64, 27)
+	a.rootPages.AddPage("settingsModal", modal, true, true)
+	a.app.SetFocus(form)
+}
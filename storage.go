@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Directory storage keeps collections as one directory per folder and one YAML file per
+// request, so a collection can be committed to a repository and reviewed in pull requests.
+// Penyimpanan direktori menyimpan Collections sebagai satu direktori per folder dan satu file
+// YAML per request, sehingga collection dapat di-commit ke repository dan di-review di pull request.
+const (
+	collectionsDirName = "collections"
+	folderMetaFile     = "_folder.yaml"
+	requestFileExt     = ".yaml"
+)
+
+// folderMeta is stored in each folder directory to keep the folder's name, child order and variables.
+// folderMeta disimpan di setiap direktori folder untuk menyimpan nama folder, urutan child dan variabelnya.
+type folderMeta struct {
+	Name      string            `yaml:"name"`
+	Order     []string          `yaml:"order,omitempty"`
+	Variables map[string]string `yaml:"variables,omitempty"`
+}
+
+// isDirectory reports whether path exists and is a directory.
+// isDirectory melaporkan apakah path ada dan merupakan direktori.
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// loadCollectionDir reads a collection tree stored with saveCollectionDir. Entries listed in
+// a folder's order come first; files added by hand or by a merge follow in name order. /
+// loadCollectionDir membaca tree collection yang disimpan dengan saveCollectionDir. Entri yang
+// tercantum di urutan folder muncul lebih dulu; file yang ditambahkan manual atau lewat merge
+// menyusul sesuai urutan nama.
+func loadCollectionDir(dir string) (*CollectionNode, error) {
+	node := &CollectionNode{Name: filepath.Base(dir), IsFolder: true}
+	var meta folderMeta
+	if data, err := os.ReadFile(filepath.Join(dir, folderMetaFile)); err == nil {
+		if err := yaml.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", filepath.Join(dir, folderMetaFile), err)
+		}
+		if meta.Name != "" {
+			node.Name = meta.Name
+		}
+		node.Variables = meta.Variables
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[string]bool)
+	var rest []string
+	for _, e := range entries {
+		if !e.IsDir() && (e.Name() == folderMetaFile || filepath.Ext(e.Name()) != requestFileExt) {
+			continue
+		}
+		present[e.Name()] = true
+		rest = append(rest, e.Name())
+	}
+	var names []string
+	for _, name := range meta.Order {
+		if present[name] {
+			names = append(names, name)
+			delete(present, name)
+		}
+	}
+	for _, name := range rest {
+		if present[name] {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if isDirectory(path) {
+			child, err := loadCollectionDir(path)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, child)
+			continue
+		}
+		req, err := readRequestFile(path)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, &CollectionNode{Name: req.Name, Request: req})
+	}
+	return node, nil
+}
+
+// readRequestFile reads one request file written by saveCollectionDir.
+// readRequestFile membaca satu file request yang ditulis oleh saveCollectionDir.
+func readRequestFile(path string) (*Request, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	// The YAML fields use the same names as collections.json.
+	// Field YAML memakai nama yang sama dengan collections.json.
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", path, err)
+	}
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return nil, fmt.Errorf("converting %s: %w", path, err)
+	}
+	if req.Name == "" {
+		req.Name = strings.TrimSuffix(filepath.Base(path), requestFileExt)
+	}
+	return &req, nil
+}
+
+// encodeRequestFile renders a request as YAML with sorted keys. The save time and recorded
+// response are left out so saving an unchanged request produces no diff. /
+// encodeRequestFile menampilkan request sebagai YAML dengan key yang terurut. Waktu simpan dan
+// response yang direkam tidak disertakan agar menyimpan request yang tidak berubah tidak menghasilkan diff.
+func encodeRequestFile(req *Request) ([]byte, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "time")
+	delete(fields, "response")
+	for k, v := range fields {
+		if m, ok := v.(map[string]interface{}); v == nil || ok && len(m) == 0 {
+			delete(fields, k)
+		}
+	}
+	return encodeYAML(fields)
+}
+
+// encodeYAML marshals v as YAML with two-space indentation.
+// encodeYAML melakukan marshal v sebagai YAML dengan indentasi dua spasi.
+func encodeYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// saveCollectionDir writes node into dir, one file per request. Unchanged files are not
+// rewritten, and request files and folders that no longer exist in the tree are removed. /
+// saveCollectionDir menulis node ke dir, satu file per request. File yang tidak berubah tidak
+// ditulis ulang, dan file request serta folder yang sudah tidak ada di tree akan dihapus.
+func saveCollectionDir(node *CollectionNode, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	meta := folderMeta{Name: node.Name, Variables: node.Variables}
+	used := make(map[string]bool)
+	for _, child := range node.Children {
+		base := fileSafeName(child.Name)
+		ext := requestFileExt
+		if child.IsFolder {
+			ext = ""
+		}
+		fileName := base + ext
+		for n := 2; used[strings.ToLower(fileName)]; n++ {
+			fileName = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		used[strings.ToLower(fileName)] = true
+		meta.Order = append(meta.Order, fileName)
+
+		path := filepath.Join(dir, fileName)
+		if child.IsFolder {
+			if err := saveCollectionDir(child, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if child.Request == nil {
+			continue
+		}
+		req := *child.Request
+		req.Name = child.Name
+		data, err := encodeRequestFile(&req)
+		if err != nil {
+			return fmt.Errorf("encoding request '%s': %w", child.Name, err)
+		}
+		if err := writeFileIfChanged(path, data); err != nil {
+			return err
+		}
+	}
+
+	data, err := encodeYAML(meta)
+	if err != nil {
+		return err
+	}
+	if err := writeFileIfChanged(filepath.Join(dir, folderMetaFile), data); err != nil {
+		return err
+	}
+	return removeStaleEntries(dir, used)
+}
+
+// removeStaleEntries deletes request files and folder directories in dir that are not in keep.
+// Other files are left alone. /
+// removeStaleEntries menghapus file request dan direktori folder di dir yang tidak ada di keep.
+// File lain dibiarkan.
+func removeStaleEntries(dir string, keep map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if keep[strings.ToLower(name)] || name == folderMetaFile {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		switch {
+		case e.IsDir():
+			if _, err := os.Stat(filepath.Join(path, folderMetaFile)); err == nil {
+				if err := os.RemoveAll(path); err != nil {
+					return err
+				}
+			}
+		case filepath.Ext(name) == requestFileExt:
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeFileIfChanged writes data to path unless the file already has exactly that content.
+// writeFileIfChanged menulis data ke path kecuali file tersebut sudah berisi konten yang sama persis.
+func writeFileIfChanged(path string, data []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return writeFileAtomic(path, data, 0644)
+}
+
+// fileSafeName turns a collection name into a portable file name.
+// fileSafeName mengubah nama collection menjadi nama file yang portabel.
+func fileSafeName(name string) string {
+	safe := strings.Trim(unsafeFileChars.ReplaceAllString(name, "_"), "_.")
+	if safe == "" {
+		return "unnamed"
+	}
+	return safe
+}
+
+// convertCollectionStorage switches the collections in the config directory between the
+// single collections.json file ("json") and directory storage ("files"). The previous
+// storage is kept with a .bak suffix. /
+// convertCollectionStorage mengganti penyimpanan Collections di direktori config antara satu
+// file collections.json ("json") dan penyimpanan direktori ("files"). Penyimpanan sebelumnya
+// disimpan dengan akhiran .bak.
+func (a *App) convertCollectionStorage(kind string) (string, error) {
+	jsonPath, err := getWorkspacePath("collections.json")
+	if err != nil {
+		return "", err
+	}
+	dirPath, err := getWorkspacePath(collectionsDirName)
+	if err != nil {
+		return "", err
+	}
+
+	switch kind {
+	case "files":
+		if isDirectory(dirPath) {
+			return dirPath, fmt.Errorf("collections are already stored in %s", dirPath)
+		}
+		// Like saveFile, never replace collections that could not be loaded.
+		// Seperti saveFile, jangan pernah mengganti Collections yang gagal dimuat.
+		if err := loadError(jsonPath); err != nil {
+			return dirPath, fmt.Errorf("not converting %s because it could not be loaded (%v); fix or move it", jsonPath, err)
+		}
+		root, err := sealCollection(a.persistedCollections())
+		if err != nil {
+			return dirPath, err
+		}
+		if err := saveCollectionDir(root, dirPath); err != nil {
+			return dirPath, err
+		}
+		if _, err := os.Stat(jsonPath); err == nil {
+			return dirPath, os.Rename(jsonPath, jsonPath+".bak")
+		}
+		return dirPath, nil
+	case "json":
+		if !isDirectory(dirPath) {
+			return jsonPath, fmt.Errorf("collections are already stored in %s", jsonPath)
+		}
+		if err := loadError(dirPath); err != nil {
+			return jsonPath, fmt.Errorf("not converting %s because it could not be loaded (%v); fix or move it", dirPath, err)
+		}
+		if err := os.RemoveAll(dirPath + ".bak"); err != nil {
+			return jsonPath, err
+		}
+		if err := os.Rename(dirPath, dirPath+".bak"); err != nil {
+			return jsonPath, err
+		}
+		a.saveCollections()
+		return jsonPath, nil
+	default:
+		return "", fmt.Errorf("unknown storage %q (want json or files)", kind)
+	}
+}
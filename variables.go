@@ -0,0 +1,496 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Variables are resolved from these scopes, lowest precedence first: global (stored on the
+// Collections root), collection folders from the outermost to the innermost, the active
+// environment, the request itself, and the overrides given with `send --var` or by the data row
+// of a run. A later scope overrides an earlier one, so folders carry defaults that an
+// environment can override for a target. /
+// Variabel di-resolve dari scope berikut, mulai dari prioritas terendah: global (disimpan di
+// root Collections), folder collection dari yang terluar sampai yang terdalam, environment
+// aktif, request itu sendiri, dan override yang diberikan dengan `send --var` atau oleh baris
+// data dari sebuah run. Scope yang lebih akhir menimpa scope sebelumnya, sehingga folder
+// membawa nilai default yang dapat ditimpa environment untuk sebuah target.
+
+// variableScope is one layer of variables and the name shown for it in the inspector.
+// variableScope adalah satu lapisan variabel beserta nama yang ditampilkan untuknya di inspector.
+type variableScope struct {
+	Name string
+	Vars map[string]string
+}
+
+// variablePattern matches a {{name}} placeholder.
+// variablePattern mencocokkan placeholder {{name}}.
+var variablePattern = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+// mergeScopes returns the value of every variable defined in scopes, later scopes winning.
+// mergeScopes mengembalikan nilai setiap variabel yang didefinisikan di scopes, scope yang lebih akhir menang.
+func mergeScopes(scopes []variableScope) map[string]string {
+	vars := make(map[string]string)
+	for _, scope := range scopes {
+		for k, v := range scope.Vars {
+			vars[k] = v
+		}
+	}
+	return vars
+}
+
+// resolveVariable reports the value of name, the scope that supplied it and the lower
+// scopes it overrides. ok is false when no scope defines name. /
+// resolveVariable melaporkan nilai dari name, scope yang menyediakannya, dan scope yang lebih
+// rendah yang ditimpanya. ok bernilai false jika tidak ada scope yang mendefinisikan name.
+func resolveVariable(scopes []variableScope, name string) (value, scope string, overrides []string, ok bool) {
+	for _, s := range scopes {
+		v, defined := s.Vars[name]
+		if !defined {
+			continue
+		}
+		if ok {
+			overrides = append(overrides, scope)
+		}
+		value, scope, ok = v, s.Name, true
+	}
+	return value, scope, overrides, ok
+}
+
+// findVariableNames returns the names of the {{variables}} used in texts, in order of first use.
+// findVariableNames mengembalikan nama {{variabel}} yang dipakai di texts, sesuai urutan pertama kali dipakai.
+func findVariableNames(texts ...string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, text := range texts {
+		for _, m := range variablePattern.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// collectionPath returns the nodes from root down to target, or nil when target is not in the tree.
+// collectionPath mengembalikan node dari root sampai target, atau nil jika target tidak ada di tree.
+func collectionPath(root, target *CollectionNode) []*CollectionNode {
+	if root == target {
+		return []*CollectionNode{root}
+	}
+	for _, child := range root.Children {
+		if path := collectionPath(child, target); path != nil {
+			return append([]*CollectionNode{root}, path...)
+		}
+	}
+	return nil
+}
+
+// folderScope returns the variable scope of a collection folder.
+// folderScope mengembalikan scope variabel dari sebuah folder collection.
+func folderScope(folder *CollectionNode) variableScope {
+	return variableScope{Name: fmt.Sprintf("folder '%s'", folder.Name), Vars: folder.Variables}
+}
+
+// requestScope returns the variable scope of a request's own variables.
+// requestScope mengembalikan scope variabel dari variabel milik request itu sendiri.
+func requestScope(req *Request) variableScope {
+	return variableScope{Name: "request", Vars: req.Variables}
+}
+
+// withScope returns scopes with scope appended, without sharing the backing array.
+// withScope mengembalikan scopes dengan scope ditambahkan, tanpa berbagi array yang sama.
+func withScope(scopes []variableScope, scope variableScope) []variableScope {
+	return append(scopes[:len(scopes):len(scopes)], scope)
+}
+
+// collectionScopes returns the global scope and the scopes of the folders containing node,
+// including node itself when it is a folder. node may be nil for an unsaved request. /
+// collectionScopes mengembalikan scope global dan scope dari folder-folder yang memuat node,
+// termasuk node itu sendiri jika berupa folder. node boleh nil untuk request yang belum disimpan.
+func (a *App) collectionScopes(node *CollectionNode) []variableScope {
+	scopes := []variableScope{{Name: "global", Vars: a.collectionsRoot.Variables}}
+	if node == nil {
+		return scopes
+	}
+	for _, n := range collectionPath(a.collectionsRoot, node) {
+		if n != a.collectionsRoot && n.IsFolder {
+			scopes = append(scopes, folderScope(n))
+		}
+	}
+	return scopes
+}
+
+// environmentScope returns the scope of the active environment, including the variables it
+// links from .env files and the OS. Command variables show as $(command), since they only run
+// when a request is sent. /
+// environmentScope mengembalikan scope dari environment yang aktif, termasuk variabel yang
+// ditautkan dari file .env dan OS. Variabel perintah ditampilkan sebagai $(command), karena
+// hanya dijalankan saat request dikirim.
+func (a *App) environmentScope() variableScope {
+	if len(a.environments) == 0 || a.activeEnvIndex >= len(a.environments) {
+		return variableScope{Name: "environment"}
+	}
+	env := a.environments[a.activeEnvIndex]
+	vars := env.allVariables()
+	// Values the environment holds, e.g. from a capture, win over its command variables.
+	// Nilai yang dimiliki environment, mis. dari capture, menang atas variabel perintahnya.
+	for name, cv := range env.Commands {
+		if _, ok := vars[name]; !ok {
+			vars[name] = commandPlaceholder(cv)
+		}
+	}
+	return variableScope{Name: fmt.Sprintf("environment '%s'", env.Name), Vars: vars}
+}
+
+// editorSource returns the collection node the request on page ("http" or "grpc") was opened from, if any.
+// editorSource mengembalikan node collection asal request di page ("http" atau "grpc"), jika ada.
+func (a *App) editorSource(page string) *CollectionNode {
+	if page == "grpc" {
+		return a.grpcSource
+	}
+	return a.httpSource
+}
+
+// sourceVariables returns the request-local variables of the collection request the editor
+// was opened from, so they are kept when the request is sent or saved again. /
+// sourceVariables mengembalikan variabel lokal request dari request collection yang dibuka di
+// editor, sehingga tetap terbawa saat request dikirim atau disimpan lagi.
+func sourceVariables(source *CollectionNode) map[string]string {
+	if source == nil || source.Request == nil {
+		return nil
+	}
+	return source.Request.Variables
+}
+
+// editorScopes returns every scope that applies to the request on page, lowest precedence first.
+// editorScopes mengembalikan semua scope yang berlaku untuk request di page, mulai dari prioritas terendah.
+func (a *App) editorScopes(page string) []variableScope {
+	source := a.editorSource(page)
+	scopes := append(a.collectionScopes(source), a.environmentScope())
+	return append(scopes, variableScope{Name: "request", Vars: sourceVariables(source)})
+}
+
+// openCollectionRequest loads a saved request into its editor and remembers where it came
+// from, so its folder and request variables apply. /
+// openCollectionRequest memuat request tersimpan ke editor-nya dan mengingat asalnya, sehingga
+// variabel folder dan request-nya berlaku.
+func (a *App) openCollectionRequest(node *CollectionNode) {
+	req := node.Request
+	if req == nil {
+		return
+	}
+	if req.Type == "grpc" {
+		a.loadGrpcRequest(*req)
+		a.grpcSource = node
+	} else {
+		a.loadRequest(*req)
+		a.httpSource = node
+	}
+}
+
+// showScopeVariablesModal edits the variables of the selected Collections node: global
+// variables on the root, folder variables on a folder and request variables on a request. /
+// showScopeVariablesModal mengedit variabel dari node Collections yang dipilih: variabel global
+// pada root, variabel folder pada folder, dan variabel request pada request.
+func (a *App) showScopeVariablesModal(node *CollectionNode) {
+	if node == nil || (!node.IsFolder && node.Request == nil) {
+		return
+	}
+	for _, n := range collectionPath(a.collectionsRoot, node) {
+		if n.SourceFile != "" {
+			a.showLiveFileVariablesNote(n)
+			return
+		}
+	}
+
+	var title string
+	var vars *map[string]string
+	switch {
+	case node == a.collectionsRoot:
+		title, vars = " Global Variables ", &node.Variables
+	case node.IsFolder:
+		title, vars = fmt.Sprintf(" Folder Variables: %s ", node.Name), &node.Variables
+	default:
+		title, vars = fmt.Sprintf(" Request Variables: %s ", node.Name), &node.Request.Variables
+	}
+	if *vars == nil {
+		*vars = make(map[string]string)
+	}
+	// The variable forms edit an Environment; this one shares the scope's map.
+	// Form variabel mengedit sebuah Environment; yang ini berbagi map milik scope tersebut.
+	scope := &Environment{Name: node.Name, Variables: *vars}
+
+	varList := tview.NewList().ShowSecondaryText(true)
+	refresh := func() {
+		varList.Clear()
+		keys := make([]string, 0, len(scope.Variables))
+		for k := range scope.Variables {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			displayValue := scope.Variables[key]
+			if len(displayValue) > 50 {
+				displayValue = displayValue[:47] + "..."
+			}
+			varList.AddItem(key, displayValue, 0, nil)
+		}
+		if varList.GetItemCount() == 0 {
+			varList.AddItem("[gray]No variables", "Press 'a' to add", 0, nil)
+		}
+	}
+	refresh()
+
+	onChanged := func() {
+		if len(*vars) == 0 {
+			*vars = nil
+		}
+		a.saveCollections()
+		refresh()
+		a.app.SetFocus(varList)
+	}
+	selectedKey := func() string {
+		key, _ := varList.GetItemText(varList.GetCurrentItem())
+		if key == "[gray]No variables" {
+			return ""
+		}
+		return key
+	}
+	closeModal := func() {
+		if len(*vars) == 0 {
+			*vars = nil
+		}
+		a.rootPages.RemovePage("scopeVarsModal")
+		a.app.SetFocus(a.collectionsTree)
+	}
+
+	varList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'a':
+			*vars = scope.Variables
+			a.showAddVariableModal(scope, onChanged)
+			return nil
+		case 'e':
+			if key := selectedKey(); key != "" {
+				a.showEditVariableModal(scope, key, onChanged)
+			}
+			return nil
+		case 'd':
+			if key := selectedKey(); key != "" {
+				delete(scope.Variables, key)
+				onChanged()
+			}
+			return nil
+		}
+		if event.Key() == tcell.KeyEsc {
+			closeModal()
+			return nil
+		}
+		return event
+	})
+
+	help := tview.NewTextView().SetDynamicColors(true).
+		SetText("[yellow]a[-] add  [yellow]e[-] edit  [yellow]d[-] delete  [yellow]Esc[-] close")
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(varList, 0, 1, true).
+		AddItem(help, 1, 0, false)
+	content.SetBorder(true).SetTitle(title)
+
+	modal := a.createModal(content, 70, 18)
+	a.rootPages.AddPage("scopeVarsModal", modal, true, true)
+	a.app.SetFocus(varList)
+}
+
+// showLiveFileVariablesNote explains where the variables of a live .http folder are kept.
+// showLiveFileVariablesNote menjelaskan di mana variabel dari folder .http live disimpan.
+func (a *App) showLiveFileVariablesNote(live *CollectionNode) {
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Variables of %s are its @variables, kept in the environment '%s'. Edit them with F10.",
+			live.SourceFile, live.Name)).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.rootPages.RemovePage("liveVarsNote")
+			a.app.SetFocus(a.collectionsTree)
+		})
+	a.rootPages.AddPage("liveVarsNote", modal, true, true)
+}
+
+// editorVariableNames returns the {{variables}} used by the request on page: URL, headers,
+// body and auth fields for HTTP, server, metadata and body for gRPC. /
+// editorVariableNames mengembalikan {{variabel}} yang dipakai request di page: URL, headers,
+// body dan field auth untuk HTTP, server, metadata dan body untuk gRPC.
+func (a *App) editorVariableNames(page string) []string {
+	if page == "grpc" {
+		return findVariableNames(a.grpcServerInput.GetText(), a.grpcRequestMeta.GetText(), a.grpcRequestBody.GetText())
+	}
+	return findVariableNames(a.urlInput.GetText(), a.headersText.GetText(), a.bodyText.GetText(),
+		a.authToken.GetText(), a.authUser.GetText(), a.authPass.GetText())
+}
+
+// unresolvedVariables returns the variables used by the request on page that no scope defines.
+// unresolvedVariables mengembalikan variabel yang dipakai request di page yang tidak didefinisikan scope mana pun.
+func (a *App) unresolvedVariables(page string) []string {
+	vars := mergeScopes(a.editorScopes(page))
+	var missing []string
+	for _, name := range a.editorVariableNames(page) {
+		if _, ok := vars[name]; !ok && !isDynamicVariable(name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// checkUnresolvedVariables calls send when every variable of the request on page resolves.
+// Otherwise it lists the missing names and lets the user define them in the active
+// environment, send anyway (e.g. when a pre-request script sets them) or cancel. /
+// checkUnresolvedVariables memanggil send jika semua variabel dari request di page ter-resolve.
+// Jika tidak, daftar nama yang hilang ditampilkan dan user dapat mendefinisikannya di environment
+// aktif, tetap mengirim (mis. jika script pre-request mengisinya) atau membatalkan.
+func (a *App) checkUnresolvedVariables(page string, send func()) {
+	missing := a.unresolvedVariables(page)
+	if len(missing) == 0 {
+		send()
+		return
+	}
+	log.Printf("WARN: Request uses undefined variables: %s", strings.Join(missing, ", "))
+
+	focused := a.app.GetFocus()
+	closeModal := func() {
+		a.rootPages.RemovePage("unresolvedVarsModal")
+		a.app.SetFocus(focused)
+	}
+	env := a.environments[a.activeEnvIndex]
+
+	form := tview.NewForm()
+	inputs := make([]*tview.InputField, len(missing))
+	for i, name := range missing {
+		inputs[i] = tview.NewInputField().SetLabel(name).SetFieldWidth(40)
+		form.AddFormItem(inputs[i])
+	}
+	form.AddButton("Save & Send", func() {
+		if env.Variables == nil {
+			env.Variables = make(map[string]string)
+		}
+		defined := false
+		for i, name := range missing {
+			if value := inputs[i].GetText(); value != "" {
+				env.Variables[name] = value
+				defined = true
+			}
+		}
+		if defined {
+			a.saveEnvironments()
+		}
+		closeModal()
+		send()
+	})
+	form.AddButton("Send Anyway", func() {
+		closeModal()
+		send()
+	})
+	form.AddButton("Cancel", closeModal)
+	form.SetCancelFunc(closeModal)
+
+	note := tview.NewTextView().SetDynamicColors(true).SetWrap(true).
+		SetText(fmt.Sprintf("[red]No scope defines %s.[-] Enter values to save them in the environment '%s'.",
+			tview.Escape("{{"+strings.Join(missing, "}}, {{")+"}}"), tview.Escape(env.Name)))
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(note, 3, 0, false).
+		AddItem(form, 0, 1, true)
+	content.SetBorder(true).SetTitle(" Unresolved Variables ")
+
+	height := min(2*len(missing)+10, 30)
+	modal := a.createModal(content, 80, height)
+	a.rootPages.AddPage("unresolvedVarsModal", modal, true, true)
+	a.app.SetFocus(form)
+}
+
+// showResolvedVariablesModal lists each {{variable}} used by the request being edited with its
+// resolved value and the scope that supplied it, followed by the other variables in scope. /
+// showResolvedVariablesModal menampilkan setiap {{variabel}} yang dipakai request yang sedang
+// diedit beserta nilai hasil resolve dan scope yang menyediakannya, diikuti variabel lain yang ada di scope.
+func (a *App) showResolvedVariablesModal() {
+	page, _ := a.rootPages.GetFrontPage()
+	if page != "grpc" {
+		page = "http"
+	}
+	used := a.editorVariableNames(page)
+	scopes := a.editorScopes(page)
+	// The environment scope comes second to last; its secret values are masked unless revealed.
+	// Scope environment berada di urutan kedua dari akhir; nilai rahasianya disamarkan kecuali ditampilkan.
+	envScope := &scopes[len(scopes)-2]
+	envScope.Vars = a.maskSecrets(maps.Clone(envScope.Vars))
+
+	var b strings.Builder
+	b.WriteString("[gray]Precedence: global < folders (outer to inner) < environment < request[-]\n\n")
+	b.WriteString("[yellow]Used in this request[-]\n")
+	if len(used) == 0 {
+		b.WriteString("  [gray]No {{variables}} used[-]\n")
+	}
+	isUsed := make(map[string]bool)
+	for _, name := range used {
+		isUsed[name] = true
+		writeResolvedVariable(&b, scopes, name)
+	}
+
+	var others []string
+	for name := range mergeScopes(scopes) {
+		if !isUsed[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	if len(others) > 0 {
+		b.WriteString("\n[yellow]Other variables in scope[-]\n")
+		for _, name := range others {
+			writeResolvedVariable(&b, scopes, name)
+		}
+	}
+
+	view := tview.NewTextView().SetDynamicColors(true).SetScrollable(true).SetWrap(true)
+	view.SetText(b.String())
+	title := " Resolved Variables (unsaved request) "
+	if source := a.editorSource(page); source != nil {
+		title = fmt.Sprintf(" Resolved Variables: %s ", source.Name)
+	}
+	view.SetBorder(true).SetTitle(title)
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.rootPages.RemovePage("resolvedVarsModal")
+			return nil
+		}
+		return event
+	})
+
+	modal := a.createModal(view, 100, 24)
+	a.rootPages.AddPage("resolvedVarsModal", modal, true, true)
+	a.app.SetFocus(view)
+}
+
+// writeResolvedVariable writes one line of the resolved variables inspector.
+// writeResolvedVariable menulis satu baris dari inspector variabel hasil resolve.
+func writeResolvedVariable(b *strings.Builder, scopes []variableScope, name string) {
+	value, scope, overrides, ok := resolveVariable(scopes, name)
+	if !ok && isDynamicVariable(name) {
+		fmt.Fprintf(b, "  [green]{{%s}}[-]  [cyan]%s[-] evaluated on every send\n", tview.Escape(name), tview.Escape("[built-in]"))
+		return
+	}
+	if !ok {
+		fmt.Fprintf(b, "  [red]{{%s}}[-]  unresolved\n", tview.Escape(name))
+		return
+	}
+	fmt.Fprintf(b, "  [green]{{%s}}[-] = %s  [cyan]%s[-]", tview.Escape(name), tview.Escape(value), tview.Escape("["+scope+"]"))
+	if len(overrides) > 0 {
+		fmt.Fprintf(b, " [gray]overrides %s[-]", tview.Escape(strings.Join(overrides, ", ")))
+	}
+	b.WriteString("\n")
+}
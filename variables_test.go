@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeScopes(t *testing.T) {
+	scopes := []variableScope{
+		{Name: "global", Vars: map[string]string{"a": "global", "b": "global"}},
+		{Name: "folder", Vars: nil},
+		{Name: "environment", Vars: map[string]string{"b": "environment", "c": ""}},
+		{Name: "request", Vars: map[string]string{"a": "request"}},
+	}
+	want := map[string]string{"a": "request", "b": "environment", "c": ""}
+	if got := mergeScopes(scopes); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeScopes = %v, want %v", got, want)
+	}
+	if scopes[0].Vars["a"] != "global" {
+		t.Error("mergeScopes modified a scope")
+	}
+}
+
+func TestResolveVariable(t *testing.T) {
+	scopes := []variableScope{
+		{Name: "global", Vars: map[string]string{"host": "g", "port": "1"}},
+		{Name: "folder", Vars: map[string]string{"host": "f"}},
+		{Name: "environment", Vars: map[string]string{"host": "e", "empty": ""}},
+	}
+	tests := []struct {
+		name          string
+		wantValue     string
+		wantScope     string
+		wantOverrides []string
+		wantOK        bool
+	}{
+		{name: "host", wantValue: "e", wantScope: "environment", wantOverrides: []string{"global", "folder"}, wantOK: true},
+		{name: "port", wantValue: "1", wantScope: "global", wantOK: true},
+		{name: "empty", wantValue: "", wantScope: "environment", wantOK: true},
+		{name: "missing"},
+	}
+	for _, tt := range tests {
+		value, scope, overrides, ok := resolveVariable(scopes, tt.name)
+		if value != tt.wantValue || scope != tt.wantScope || !reflect.DeepEqual(overrides, tt.wantOverrides) || ok != tt.wantOK {
+			t.Errorf("resolveVariable(%q) = %q, %q, %v, %v, want %q, %q, %v, %v",
+				tt.name, value, scope, overrides, ok, tt.wantValue, tt.wantScope, tt.wantOverrides, tt.wantOK)
+		}
+	}
+}
+
+func TestFindVariableNames(t *testing.T) {
+	got := findVariableNames("{{baseUrl}}/users/{{id}}", `{"id": "{{id}}", "t": "{{$uuid}}"}`, "no vars", "{{ spaced }}")
+	want := []string{"baseUrl", "id", "$uuid", " spaced "}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findVariableNames = %q, want %q", got, want)
+	}
+}
+
+func TestWithScopeDoesNotShareBackingArray(t *testing.T) {
+	base := make([]variableScope, 1, 4)
+	base[0] = variableScope{Name: "global"}
+	first := withScope(base, variableScope{Name: "a"})
+	second := withScope(base, variableScope{Name: "b"})
+	if first[1].Name != "a" || second[1].Name != "b" {
+		t.Errorf("withScope results share storage: %v, %v", first, second)
+	}
+}
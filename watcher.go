@@ -0,0 +1,364 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// fileWatchInterval is how often the collections and environments files are checked for
+// changes made outside panggil, such as a git pull or an edit in another editor. /
+// fileWatchInterval adalah seberapa sering file collections dan environments diperiksa untuk
+// perubahan yang dibuat di luar panggil, seperti git pull atau edit di editor lain.
+const fileWatchInterval = 2 * time.Second
+
+// watchedFile is a file or directory panggil reloads when it changes on disk.
+// watchedFile adalah file atau direktori yang dimuat ulang panggil saat berubah di disk.
+type watchedFile struct {
+	path   string
+	name   string
+	reload func()
+	save   func()
+}
+
+// watchSignatures keeps the stat signature of each watched path at its last check, so
+// unchanged files are not hashed again. /
+// watchSignatures menyimpan signature stat dari setiap path yang dipantau saat pemeriksaan
+// terakhir, sehingga file yang tidak berubah tidak di-hash ulang.
+var watchSignatures = struct {
+	sync.Mutex
+	sigs map[string]string
+}{sigs: make(map[string]string)}
+
+// forgetSignature makes the next check look at path again, for a change that was not handled.
+// forgetSignature membuat pemeriksaan berikutnya melihat path lagi, untuk perubahan yang belum ditangani.
+func forgetSignature(path string) {
+	watchSignatures.Lock()
+	defer watchSignatures.Unlock()
+	delete(watchSignatures.sigs, path)
+}
+
+// contentFingerprint returns the fingerprint of file content.
+// contentFingerprint mengembalikan fingerprint dari isi file.
+func contentFingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// fileFingerprint returns the fingerprint of the file at path, or of the request and folder
+// files inside a collections directory, and "" when path does not exist. /
+// fileFingerprint mengembalikan fingerprint dari file di path, atau dari file request dan folder
+// di dalam direktori collections, dan "" jika path tidak ada.
+func fileFingerprint(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+		return contentFingerprint(data)
+	}
+	h := sha256.New()
+	walkCollectionFiles(path, func(rel, file string, _ fs.FileInfo) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", rel, len(data))
+		h.Write(data)
+	})
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// statSignature summarizes the modification times and sizes of path, or of the files inside a
+// collections directory, so a change can be noticed without reading the files. /
+// statSignature merangkum waktu modifikasi dan ukuran path, atau file di dalam direktori
+// collections, sehingga perubahan dapat diketahui tanpa membaca file-nya.
+func statSignature(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	if !info.IsDir() {
+		return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+	}
+	var sig strings.Builder
+	walkCollectionFiles(path, func(rel, _ string, info fs.FileInfo) {
+		fmt.Fprintf(&sig, "%s:%d:%d;", rel, info.ModTime().UnixNano(), info.Size())
+	})
+	return sig.String()
+}
+
+// walkCollectionFiles calls fn for every request and folder file below dir, in lexical order.
+// walkCollectionFiles memanggil fn untuk setiap file request dan folder di bawah dir, berurutan secara leksikal.
+func walkCollectionFiles(dir string, fn func(rel, path string, info fs.FileInfo)) {
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != requestFileExt {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		fn(rel, path, info)
+		return nil
+	})
+}
+
+// collectionsStoragePath returns the collections directory when one exists, or else the
+// collections file, of the active workspace. /
+// collectionsStoragePath mengembalikan direktori collections jika ada, atau file collections,
+// dari workspace yang aktif.
+func collectionsStoragePath() string {
+	if dir, err := getWorkspacePath(collectionsDirName); err == nil && isDirectory(dir) {
+		return dir
+	}
+	path, _ := getWorkspacePath("collections.json")
+	return path
+}
+
+// watchedFiles returns the collections and environments files of the active workspace.
+// watchedFiles mengembalikan file collections dan environments dari workspace yang aktif.
+func (a *App) watchedFiles() []watchedFile {
+	var files []watchedFile
+	if path := collectionsStoragePath(); path != "" {
+		files = append(files, watchedFile{path: path, name: filepath.Base(path), reload: a.reloadCollections, save: a.saveCollections})
+	}
+	if path, err := getWorkspacePath("environments.json"); err == nil {
+		files = append(files, watchedFile{path: path, name: "environments.json", reload: a.reloadEnvironments, save: a.saveEnvironments})
+	}
+	return files
+}
+
+// startFileWatcher checks the watched files for external changes every fileWatchInterval. The
+// files are read in the background, and the UI is only updated when one of them changed. /
+// startFileWatcher memeriksa file yang dipantau untuk perubahan eksternal setiap fileWatchInterval.
+// File dibaca di background, dan UI hanya diperbarui jika salah satunya berubah.
+func (a *App) startFileWatcher() {
+	go func() {
+		ticker := time.NewTicker(fileWatchInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if changed := changedFiles(); len(changed) > 0 {
+				a.app.QueueUpdateDraw(func() { a.checkExternalChanges(changed) })
+			}
+		}
+	}()
+}
+
+// changedFiles returns the fingerprints of the watched paths that differ from what panggil last
+// loaded or saved. Paths whose stat signature did not change since the last check are skipped. /
+// changedFiles mengembalikan fingerprint dari path dipantau yang berbeda dengan yang terakhir
+// dimuat atau disimpan panggil. Path yang signature stat-nya tidak berubah sejak pemeriksaan
+// terakhir dilewati.
+func changedFiles() map[string]string {
+	var paths []string
+	if path := collectionsStoragePath(); path != "" {
+		paths = append(paths, path)
+	}
+	if path, err := getWorkspacePath("environments.json"); err == nil {
+		paths = append(paths, path)
+	}
+
+	changed := make(map[string]string)
+	for _, path := range paths {
+		sig := statSignature(path)
+		watchSignatures.Lock()
+		prev, ok := watchSignatures.sigs[path]
+		watchSignatures.sigs[path] = sig
+		watchSignatures.Unlock()
+		if ok && prev == sig {
+			continue
+		}
+		fingerprint := fileFingerprint(path)
+		if known, _ := syncedFingerprint(path); fingerprint != known {
+			changed[path] = fingerprint
+		}
+	}
+	return changed
+}
+
+// checkExternalChanges reloads the watched files in changed, which changed on disk since panggil
+// last loaded or saved them. When a save was refused because of such a change, the user chooses
+// which side to keep. Nothing is reloaded while a modal is open; the change is looked at again
+// on the next check. /
+// checkExternalChanges memuat ulang file dipantau di changed, yang berubah di disk sejak terakhir
+// dimuat atau disimpan panggil. Jika penyimpanan ditolak karena perubahan tersebut, pengguna
+// memilih sisi mana yang dipertahankan. Tidak ada yang dimuat ulang selama modal terbuka;
+// perubahannya diperiksa lagi pada pemeriksaan berikutnya.
+func (a *App) checkExternalChanges(changed map[string]string) {
+	if page, _ := a.rootPages.GetFrontPage(); page != "http" && page != "grpc" {
+		for path := range changed {
+			forgetSignature(path)
+		}
+		return
+	}
+	for _, w := range a.watchedFiles() {
+		fingerprint, ok := changed[w.path]
+		if !ok {
+			continue
+		}
+		delete(changed, w.path)
+		// panggil may have saved the same content since the file was read.
+		// panggil mungkin sudah menyimpan isi yang sama sejak file dibaca.
+		if known, _ := syncedFingerprint(w.path); fingerprint == known {
+			continue
+		}
+		if hasConflict(w.path) {
+			for path := range changed {
+				forgetSignature(path)
+			}
+			a.showExternalChangeModal(w, fingerprint)
+			return
+		}
+		log.Printf("INFO: %s changed on disk, reloading", w.path)
+		w.reload()
+		if err := loadError(w.path); err != nil {
+			a.statusText.SetText(fmt.Sprintf("[red]%s changed on disk but could not be loaded (see log)", w.name))
+		} else {
+			a.statusText.SetText(fmt.Sprintf("[yellow]Reloaded %s after an external change", w.name))
+		}
+	}
+}
+
+// showExternalChangeModal asks whether to reload w, discarding the changes made in panggil,
+// or to overwrite the version on disk with them. /
+// showExternalChangeModal menanyakan apakah w dimuat ulang, membuang perubahan yang dibuat di
+// panggil, atau versi di disk ditimpa dengan perubahan tersebut.
+func (a *App) showExternalChangeModal(w watchedFile, fingerprint string) {
+	returnFocus := a.app.GetFocus()
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("%s was changed outside panggil, and your latest changes could not be saved.\n\nReload it and discard your changes, or keep yours and overwrite the file?", w.name)).
+		AddButtons([]string{"Reload", "Keep Mine"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			a.rootPages.RemovePage("externalChangeModal")
+			a.app.SetFocus(returnFocus)
+			forgetSignature(w.path)
+			if buttonLabel == "Keep Mine" {
+				markSynced(w.path, fingerprint)
+				w.save()
+				log.Printf("INFO: Overwrote external changes to %s", w.path)
+				a.statusText.SetText(fmt.Sprintf("[yellow]Kept your changes, overwrote %s", w.name))
+				return
+			}
+			w.reload()
+			log.Printf("INFO: Discarded local changes, reloaded %s", w.path)
+			a.statusText.SetText(fmt.Sprintf("[yellow]Reloaded %s, your changes were discarded", w.name))
+		})
+	a.rootPages.AddPage("externalChangeModal", modal, true, true)
+	a.app.SetFocus(modal)
+}
+
+// reloadCollections loads the collections again, keeping live .http folders, the selected tree
+// node and the requests open in the editors when they still exist. A file that no longer
+// loads leaves the current tree in place. /
+// reloadCollections memuat ulang Collections, dengan tetap mempertahankan folder .http live,
+// node tree yang dipilih dan request yang terbuka di editor jika masih ada. File yang tidak
+// dapat dimuat membiarkan tree saat ini tetap ada.
+func (a *App) reloadCollections() {
+	old := a.collectionsRoot
+	nodePath := func(node *CollectionNode) string {
+		path := collectionPath(old, node)
+		if len(path) < 2 {
+			return ""
+		}
+		names := make([]string, 0, len(path)-1)
+		for _, n := range path[1:] {
+			names = append(names, n.Name)
+		}
+		return strings.Join(names, "/")
+	}
+	var selected string
+	if current := a.collectionsTree.GetCurrentNode(); current != nil {
+		if node, ok := current.GetReference().(*CollectionNode); ok {
+			selected = nodePath(node)
+		}
+	}
+	httpSource, grpcSource := nodePath(a.httpSource), nodePath(a.grpcSource)
+	expanded := make(map[string]bool)
+	var collectExpanded func(node *CollectionNode, path string)
+	collectExpanded = func(node *CollectionNode, path string) {
+		expanded[path] = node.Expanded
+		for _, child := range node.Children {
+			if child.IsFolder {
+				collectExpanded(child, strings.TrimPrefix(path+"/"+child.Name, "/"))
+			}
+		}
+	}
+	collectExpanded(old, "")
+
+	a.collectionsRoot = &CollectionNode{Name: old.Name, IsFolder: true, Expanded: old.Expanded}
+	a.loadCollections()
+	if loadError(collectionsStoragePath()) != nil {
+		a.collectionsRoot = old
+		return
+	}
+	for _, child := range old.Children {
+		if child.SourceFile != "" {
+			a.collectionsRoot.Children = append(a.collectionsRoot.Children, child)
+		}
+	}
+
+	for path, open := range expanded {
+		if node := findNodeByPath(a.collectionsRoot, path); node != nil {
+			node.Expanded = open
+		}
+	}
+
+	resolve := func(path string) *CollectionNode {
+		if path == "" {
+			return nil
+		}
+		return findNodeByPath(a.collectionsRoot, path)
+	}
+	a.httpSource, a.grpcSource = resolve(httpSource), resolve(grpcSource)
+	a.populateCollectionsTree()
+	if target := resolve(selected); target != nil {
+		a.collectionsTree.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+			if node.GetReference() == target {
+				a.collectionsTree.SetCurrentNode(node)
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// reloadEnvironments loads the environments again, keeping the active environment by name.
+// A file that no longer loads leaves the current environments in place. /
+// reloadEnvironments memuat ulang environments, dengan tetap mempertahankan environment yang
+// aktif berdasarkan nama. File yang tidak dapat dimuat membiarkan environments saat ini tetap ada.
+func (a *App) reloadEnvironments() {
+	old, oldIndex := a.environments, a.activeEnvIndex
+	activeName := ""
+	if oldIndex < len(old) {
+		activeName = old[oldIndex].Name
+	}
+
+	a.environments = nil
+	a.loadEnvironments()
+	if path, err := getWorkspacePath("environments.json"); err == nil && loadError(path) != nil {
+		a.environments, a.activeEnvIndex = old, oldIndex
+		return
+	}
+	for i, env := range a.environments {
+		if env.Name == activeName {
+			a.activeEnvIndex = i
+		}
+	}
+	a.refreshEnvDropdown()
+	a.confirmWorkspaceCommands()
+}
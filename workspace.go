@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// workspaceDirName is the directory that marks a project-local workspace.
+// workspaceDirName adalah direktori yang menandai workspace lokal sebuah project.
+const workspaceDirName = ".panggil"
+
+// maxRecentWorkspaces limits the workspaces remembered for the workspace switcher.
+// maxRecentWorkspaces membatasi jumlah workspace yang diingat untuk pemilih workspace.
+const maxRecentWorkspaces = 10
+
+// activeWorkspace holds the .panggil directory of the active workspace, or "" when collections,
+// environments and the gRPC cache are stored in the user config directory. The file watcher and
+// command variables read it off the UI goroutine, so it is guarded. /
+// activeWorkspace menyimpan direktori .panggil dari workspace yang aktif, atau "" jika Collections,
+// environments dan cache gRPC disimpan di direktori config pengguna. File watcher dan variabel
+// perintah membacanya di luar goroutine UI, sehingga dijaga dengan mutex.
+var activeWorkspace struct {
+	sync.RWMutex
+	dir string
+}
+
+// workspaceDir returns the .panggil directory of the active workspace, or "" for the global one.
+// workspaceDir mengembalikan direktori .panggil dari workspace yang aktif, atau "" untuk workspace global.
+func workspaceDir() string {
+	activeWorkspace.RLock()
+	defer activeWorkspace.RUnlock()
+	return activeWorkspace.dir
+}
+
+// setWorkspaceDir makes dir the active workspace.
+// setWorkspaceDir menjadikan dir sebagai workspace yang aktif.
+func setWorkspaceDir(dir string) {
+	activeWorkspace.Lock()
+	defer activeWorkspace.Unlock()
+	activeWorkspace.dir = dir
+}
+
+// getWorkspacePath returns the path of a file that belongs to the active workspace.
+// getWorkspacePath mengembalikan path sebuah file milik workspace yang aktif.
+func getWorkspacePath(filename string) (string, error) {
+	dir := workspaceDir()
+	if dir == "" {
+		return getConfigPath(filename)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create workspace dir: %w", err)
+	}
+	return filepath.Join(dir, filename), nil
+}
+
+// findWorkspace looks for a .panggil directory in start and its parents, like git does for
+// .git. It returns "" when there is none. /
+// findWorkspace mencari direktori .panggil di start dan parent-nya, seperti git mencari .git.
+// Mengembalikan "" jika tidak ada.
+func findWorkspace(start string) string {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return ""
+	}
+	home, _ := os.UserHomeDir()
+	for {
+		candidate := filepath.Join(dir, workspaceDirName)
+		// The home directory's .panggil is not a project workspace.
+		// .panggil di direktori home bukan workspace project.
+		if dir != home && isDirectory(candidate) {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// resolveWorkspace turns a --workspace argument (a project directory or its .panggil
+// directory) into the .panggil directory, creating it when missing. The project directory must
+// already exist. /
+// resolveWorkspace mengubah argumen --workspace (direktori project atau direktori .panggil-nya)
+// menjadi direktori .panggil, dan membuatnya jika belum ada. Direktori project harus sudah ada.
+func resolveWorkspace(path string) (string, error) {
+	abs, err := filepath.Abs(expandHomePath(path))
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(abs) != workspaceDirName {
+		abs = filepath.Join(abs, workspaceDirName)
+	}
+	// Only .panggil is created, so a mistyped project path is reported instead of created.
+	// Hanya .panggil yang dibuat, sehingga path project yang salah ketik dilaporkan alih-alih dibuat.
+	if project := filepath.Dir(abs); !isDirectory(project) {
+		return "", fmt.Errorf("project directory %s does not exist", project)
+	}
+	if err := os.MkdirAll(abs, 0755); err != nil {
+		return "", fmt.Errorf("could not create workspace: %w", err)
+	}
+	return abs, nil
+}
+
+// extractWorkspaceFlag removes a leading --workspace flag from args and returns its value.
+// extractWorkspaceFlag menghapus flag --workspace di awal args dan mengembalikan nilainya.
+func extractWorkspaceFlag(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", args, nil
+	}
+	switch {
+	case args[0] == "--workspace" || args[0] == "-workspace":
+		if len(args) < 2 {
+			return "", nil, fmt.Errorf("--workspace requires a directory")
+		}
+		return args[1], args[2:], nil
+	case strings.HasPrefix(args[0], "--workspace="):
+		return strings.TrimPrefix(args[0], "--workspace="), args[1:], nil
+	}
+	return "", args, nil
+}
+
+// workspaceName returns a short display name for a workspace directory.
+// workspaceName mengembalikan nama tampilan singkat untuk direktori workspace.
+func workspaceName(dir string) string {
+	if dir == "" {
+		return "Global"
+	}
+	return filepath.Base(filepath.Dir(dir))
+}
+
+// loadRecentWorkspaces reads the recently used workspaces, skipping those that no longer exist.
+// loadRecentWorkspaces membaca workspace yang baru-baru ini dipakai, melewati yang sudah tidak ada.
+func loadRecentWorkspaces() []string {
+	path, err := getConfigPath("workspaces.json")
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	if err := json.Unmarshal(data, &dirs); err != nil {
+		log.Printf("ERROR: Failed to unmarshal recent workspaces: %v", err)
+		return nil
+	}
+	var existing []string
+	for _, dir := range dirs {
+		if isDirectory(dir) {
+			existing = append(existing, dir)
+		}
+	}
+	return existing
+}
+
+// rememberWorkspace moves dir to the front of the recently used workspaces.
+// rememberWorkspace memindahkan dir ke urutan pertama workspace yang baru-baru ini dipakai.
+func rememberWorkspace(dir string) {
+	if dir == "" {
+		return
+	}
+	dirs := []string{dir}
+	for _, d := range loadRecentWorkspaces() {
+		if d != dir && len(dirs) < maxRecentWorkspaces {
+			dirs = append(dirs, d)
+		}
+	}
+	path, err := getConfigPath("workspaces.json")
+	if err != nil {
+		log.Printf("ERROR: Could not get config path for workspaces: %v", err)
+		return
+	}
+	data, _ := json.MarshalIndent(dirs, "", "  ")
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		log.Printf("ERROR: Failed to write recent workspaces: %v", err)
+	}
+}
+
+// switchWorkspace saves the current workspace and loads collections, environments and the
+// gRPC cache of dir ("" for the user config directory). Live .http folders stay open. /
+// switchWorkspace menyimpan workspace saat ini lalu memuat Collections, environments dan cache
+// gRPC dari dir ("" untuk direktori config pengguna). Folder .http live tetap terbuka.
+func (a *App) switchWorkspace(dir string) {
+	a.saveCollections()
+	a.saveGrpcCache()
+	a.saveEnvironments()
+
+	var liveFolders []*CollectionNode
+	for _, child := range a.collectionsRoot.Children {
+		if child.SourceFile != "" {
+			liveFolders = append(liveFolders, child)
+		}
+	}
+
+	setWorkspaceDir(dir)
+	rememberWorkspace(dir)
+	a.collectionsRoot = &CollectionNode{Name: "Collections", IsFolder: true, Expanded: true}
+	a.grpcBodyCache = make(map[string]string)
+	a.environments = nil
+	a.activeEnvIndex = 0
+	a.loadCollections()
+	a.loadGrpcCache()
+	a.loadEnvironments()
+	a.collectionsRoot.Children = append(a.collectionsRoot.Children, liveFolders...)
+	a.reportLoadFailures()
+
+	a.populateCollectionsTree()
+	a.updateWorkspaceButton()
+	a.refreshEnvDropdown()
+	a.confirmWorkspaceCommands()
+	log.Printf("INFO: Switched to workspace '%s' (%s)", workspaceName(dir), dir)
+}
+
+// updateWorkspaceButton shows the active workspace in the header bar.
+// updateWorkspaceButton menampilkan workspace yang aktif di header bar.
+func (a *App) updateWorkspaceButton() {
+	if a.workspaceBtn != nil {
+		a.workspaceBtn.SetLabel(fmt.Sprintf("WS: %s (Ctrl+O)", workspaceName(workspaceDir())))
+	}
+}
+
+// showWorkspaceModal lists the global workspace and the recently used project workspaces,
+// and can open another project directory as a workspace. /
+// showWorkspaceModal menampilkan workspace global dan workspace project yang baru-baru ini
+// dipakai, serta dapat membuka direktori project lain sebagai workspace.
+func (a *App) showWorkspaceModal() {
+	dirs := append([]string{""}, loadRecentWorkspaces()...)
+	current := workspaceDir()
+	if current != "" && !slices.Contains(dirs, current) {
+		dirs = append(dirs, current)
+	}
+
+	closeModal := func() {
+		a.rootPages.RemovePage("workspaceModal")
+		a.app.SetFocus(a.collectionsTree)
+	}
+
+	list := tview.NewList().ShowSecondaryText(true)
+	for _, dir := range dirs {
+		label := workspaceName(dir)
+		if dir == current {
+			label = "[green]● " + label + "[-]"
+		}
+		location := dir
+		if dir == "" {
+			location, _ = getConfigPath("")
+		}
+		list.AddItem(label, location, 0, nil)
+	}
+	list.SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+		closeModal()
+		if dirs[index] != current {
+			a.switchWorkspace(dirs[index])
+		}
+	})
+
+	pathInput := tview.NewInputField().SetLabel("Open project: ").
+		SetPlaceholder("directory, e.g. ~/src/orders-service")
+	pathInput.SetDoneFunc(func(key tcell.Key) {
+		if key != tcell.KeyEnter {
+			return
+		}
+		path := strings.TrimSpace(pathInput.GetText())
+		if path == "" {
+			return
+		}
+		dir, err := resolveWorkspace(path)
+		if err != nil {
+			log.Printf("ERROR: Could not open workspace %s: %v", path, err)
+			pathInput.SetLabel("[red]Invalid directory[-] ")
+			return
+		}
+		closeModal()
+		a.switchWorkspace(dir)
+	})
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(list, 0, 1, true).
+		AddItem(pathInput, 1, 0, false)
+	layout.SetBorder(true).SetTitle(" Workspaces (Tab: open project, Esc: close) ")
+	layout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			closeModal()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			if list.HasFocus() {
+				a.app.SetFocus(pathInput)
+			} else {
+				a.app.SetFocus(list)
+			}
+			return nil
+		}
+		return event
+	})
+
+	modal := a.createModal(layout, 80, 16)
+	a.rootPages.AddPage("workspaceModal", modal, true, true)
+	a.app.SetFocus(list)
+}